@@ -0,0 +1,147 @@
+// Package address validates and normalizes wallet addresses on the chains
+// supported by billing.io.
+//
+// Tron addresses are base58check-encoded ("T..."); the checksum and network
+// prefix are verified. Arbitrum addresses are EVM hex addresses ("0x...");
+// mixed-case input must carry a valid EIP-55 checksum, and all addresses are
+// normalized to their checksummed form.
+//
+//	addr, err := address.Normalize("arbitrum", "0xaf88d065e77c8cc2239327c5edb3a432268e5831")
+//	// addr == "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"
+//
+// Like the tokens package, it has no dependency on the billingio package, so
+// chains are passed as plain strings (e.g. string(billingio.ChainTron)).
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalid is returned for addresses that are malformed for their chain.
+	ErrInvalid = errors.New("address: invalid address")
+
+	// ErrChecksum is returned for addresses whose checksum does not match,
+	// which usually indicates a typo.
+	ErrChecksum = errors.New("address: checksum mismatch")
+
+	// ErrUnsupportedChain is returned for chains this package does not know.
+	ErrUnsupportedChain = errors.New("address: unsupported chain")
+)
+
+// tronPrefix is the version byte of Tron mainnet addresses.
+const tronPrefix = 0x41
+
+// Validate reports whether addr is a well-formed address on chain. The
+// returned error wraps ErrInvalid, ErrChecksum or ErrUnsupportedChain.
+func Validate(chain, addr string) error {
+	_, err := Normalize(chain, addr)
+	return err
+}
+
+// Normalize validates addr and returns its canonical form on chain:
+// EIP-55 checksummed for EVM chains, unchanged for Tron. Surrounding
+// whitespace is removed.
+func Normalize(chain, addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	switch chain {
+	case "tron":
+		if err := ValidateTron(addr); err != nil {
+			return "", err
+		}
+		return addr, nil
+	case "arbitrum":
+		return NormalizeEVM(addr)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedChain, chain)
+	}
+}
+
+// ValidateTron checks that addr is a base58check-encoded Tron mainnet
+// address.
+func ValidateTron(addr string) error {
+	raw, err := decodeBase58(addr)
+	if err != nil || len(raw) != 25 {
+		return fmt.Errorf("%w: %q is not a Tron address", ErrInvalid, addr)
+	}
+	if raw[0] != tronPrefix {
+		return fmt.Errorf("%w: %q is not a Tron mainnet address", ErrInvalid, addr)
+	}
+	payload, sum := raw[:21], raw[21:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], sum) {
+		return fmt.Errorf("%w: %q", ErrChecksum, addr)
+	}
+	return nil
+}
+
+// NormalizeEVM validates an EVM hex address and returns it in EIP-55
+// checksummed form. All-lowercase and all-uppercase input is accepted;
+// mixed-case input must already carry a valid checksum.
+func NormalizeEVM(addr string) (string, error) {
+	hexPart, ok := strings.CutPrefix(addr, "0x")
+	if !ok || len(hexPart) != 40 {
+		return "", fmt.Errorf("%w: %q is not an EVM address", ErrInvalid, addr)
+	}
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", fmt.Errorf("%w: %q is not an EVM address", ErrInvalid, addr)
+	}
+	sum := checksumEVM(hexPart)
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && "0x"+hexPart != sum {
+		return "", fmt.Errorf("%w: %q", ErrChecksum, addr)
+	}
+	return sum, nil
+}
+
+// checksumEVM applies EIP-55 capitalization to a 40-character hex address
+// without its 0x prefix.
+func checksumEVM(hexPart string) string {
+	lower := strings.ToLower(hexPart)
+	hash := keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		if c < 'a' {
+			continue // digit
+		}
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// base58Alphabet is the Bitcoin base58 alphabet used by Tron.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a base58 string, preserving leading zero bytes.
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, ErrInvalid
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range s {
+		i := strings.IndexRune(base58Alphabet, r)
+		if i < 0 {
+			return nil, ErrInvalid
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(i)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,106 @@
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		// Spans two 136-byte blocks.
+		{strings.Repeat("a", 200), "96ea54061def936c4be90b518992fdc6f12f535068a256229aca54267b4d084d"},
+	}
+	for _, tt := range tests {
+		sum := keccak256([]byte(tt.in))
+		if got := hex.EncodeToString(sum[:]); got != tt.want {
+			t.Errorf("keccak256(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+// eip55Vectors are the test vectors from EIP-55.
+var eip55Vectors = []string{
+	// All caps
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	// All lower
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	// Normal
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestNormalizeEVM(t *testing.T) {
+	for _, want := range eip55Vectors {
+		if got := checksumEVM(strings.ToLower(want[2:])); got != want {
+			t.Errorf("checksumEVM(%s) = %s", want, got)
+		}
+		if got, err := NormalizeEVM(want); err != nil || got != want {
+			t.Errorf("NormalizeEVM(%s) = %s, %v", want, got, err)
+		}
+	}
+
+	// Lowercase input is accepted and checksummed.
+	got, err := Normalize("arbitrum", " 0xaf88d065e77c8cc2239327c5edb3a432268e5831 ")
+	if want := "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"; err != nil || got != want {
+		t.Errorf("Normalize = %s, %v, want %s", got, err, want)
+	}
+}
+
+func TestNormalizeEVMInvalid(t *testing.T) {
+	tests := []struct {
+		addr string
+		want error
+	}{
+		// One letter of a valid checksummed address with its case flipped.
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", ErrChecksum},
+		{"0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ErrChecksum},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", ErrInvalid},
+		{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", ErrInvalid},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg", ErrInvalid},
+	}
+	for _, tt := range tests {
+		if _, err := NormalizeEVM(tt.addr); !errors.Is(err, tt.want) {
+			t.Errorf("NormalizeEVM(%s) error = %v, want %v", tt.addr, err, tt.want)
+		}
+	}
+}
+
+func TestValidateTron(t *testing.T) {
+	// The USDT (TRC-20) contract address.
+	const usdt = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	if err := Validate("tron", usdt); err != nil {
+		t.Errorf("Validate(%s) = %v", usdt, err)
+	}
+
+	tests := []struct {
+		addr string
+		want error
+	}{
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", ErrChecksum},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6", ErrInvalid},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj60", ErrInvalid}, // '0' is not base58
+		{"", ErrInvalid},
+	}
+	for _, tt := range tests {
+		if err := ValidateTron(tt.addr); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateTron(%s) error = %v, want %v", tt.addr, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeUnsupportedChain(t *testing.T) {
+	if _, err := Normalize("bitcoin", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"); !errors.Is(err, ErrUnsupportedChain) {
+		t.Errorf("Normalize error = %v, want ErrUnsupportedChain", err)
+	}
+}
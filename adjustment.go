@@ -8,7 +8,11 @@ type AdjustmentService struct {
 }
 
 // List returns a paginated list of adjustments.
-func (s *AdjustmentService) List(ctx context.Context, params *ListAdjustmentsParams) (*AdjustmentList, error) {
+func (s *AdjustmentService) List(ctx context.Context, params *ListAdjustmentsParams, opts ...RequestOption) (*AdjustmentList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -20,7 +24,7 @@ func (s *AdjustmentService) List(ctx context.Context, params *ListAdjustmentsPar
 	path := addQueryParams("/revenue/adjustments", qp)
 
 	var list AdjustmentList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Adjustments.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -28,9 +32,20 @@ func (s *AdjustmentService) List(ctx context.Context, params *ListAdjustmentsPar
 }
 
 // Create creates a new revenue adjustment.
-func (s *AdjustmentService) Create(ctx context.Context, params *CreateAdjustmentParams) (*Adjustment, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *AdjustmentService) Create(ctx context.Context, params *CreateAdjustmentParams, opts ...RequestOption) (*Adjustment, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var adj Adjustment
-	err := s.client.post(ctx, "/revenue/adjustments", params, &adj, nil)
+	err := s.client.post(ctx, "Adjustments.Create", "/revenue/adjustments", params, &adj, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,15 +54,15 @@ func (s *AdjustmentService) Create(ctx context.Context, params *CreateAdjustment
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of adjustments. See Iter for usage details.
-func (s *AdjustmentService) ListAutoPaginate(ctx context.Context, params *ListAdjustmentsParams) *Iter[Adjustment] {
+func (s *AdjustmentService) ListAutoPaginate(ctx context.Context, params *ListAdjustmentsParams, opts ...RequestOption) *Iter[Adjustment] {
 	if params == nil {
 		params = &ListAdjustmentsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Adjustment, bool, *string, error) {
+	return newIter(ctx, s.client, "Adjustments", func(ctx context.Context, cursor *string) ([]Adjustment, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -7,7 +7,7 @@
 // All service methods accept a context.Context as their first parameter:
 //
 //	checkout, err := client.Checkouts.Create(ctx, &billingio.CreateCheckoutParams{
-//	    AmountUSD: 49.99,
+//	    AmountUSD: billingio.MustParseMoney("49.99"),
 //	    Chain:     billingio.ChainTron,
 //	    Token:     billingio.TokenUSDT,
 //	})
@@ -19,16 +19,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	defaultBaseURL = "https://api.billing.io/v1"
-	sdkVersion     = "0.1.0"
+	sdkVersion     = "0.2.0"
 )
 
 // Client is the billing.io API client. Use New to create one.
@@ -38,6 +41,24 @@ type Client struct {
 	httpClient *http.Client
 	userAgent  string
 
+	retryPolicy RetryPolicy
+
+	middleware []Middleware
+	handler    Handler
+
+	logger       *slog.Logger
+	logLevel     slog.Level
+	logRedaction LogRedaction
+
+	tracer  Tracer
+	metrics MetricsRecorder
+
+	strict         bool // reject unknown enum values in params
+	skipValidation bool // skip client-side params validation
+
+	mu        sync.Mutex
+	rateLimit *RateLimit // most recently observed rate-limit headers
+
 	// Resource services
 	Checkouts            *CheckoutService
 	Webhooks             *WebhookService
@@ -78,17 +99,20 @@ func WithHTTPClient(hc *http.Client) Option {
 //	client := billingio.New("sk_live_...",
 //	    billingio.WithBaseURL("https://api.billing.io/v1"),
 //	    billingio.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}),
+//	    billingio.WithMaxRetries(3),
 //	)
 func New(apiKey string, opts ...Option) *Client {
 	c := &Client{
-		apiKey:     apiKey,
-		baseURL:    defaultBaseURL,
-		httpClient: http.DefaultClient,
-		userAgent:  fmt.Sprintf("billing-go/%s Go/%s", sdkVersion, runtime.Version()),
+		apiKey:      apiKey,
+		baseURL:     defaultBaseURL,
+		httpClient:  http.DefaultClient,
+		userAgent:   fmt.Sprintf("billing-go/%s Go/%s", sdkVersion, runtime.Version()),
+		retryPolicy: DefaultRetryPolicy(),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.handler = c.buildHandler(c.send)
 
 	c.Checkouts = &CheckoutService{client: c}
 	c.Webhooks = &WebhookService{client: c}
@@ -111,88 +135,219 @@ func New(apiKey string, opts ...Option) *Client {
 
 // do executes an HTTP request and decodes the response into dest.
 // If dest is nil the response body is discarded (used for 204 responses).
-func (c *Client) do(ctx context.Context, method, path string, body any, dest any, headers map[string]string) error {
-	u := c.baseURL + path
+//
+// op names the SDK method making the call (e.g. "Checkouts.Create") and is
+// reported to middleware, tracers and metrics recorders.
+func (c *Client) do(ctx context.Context, op, method, path string, body any, dest any, opts []RequestOption) error {
+	cfg := newRequestConfig(opts)
+	service, name := splitOperation(op)
 
-	var reqBody io.Reader
+	ctx, span := c.startSpan(ctx, op)
+	span.SetAttribute("billingio.service", service)
+	span.SetAttribute("billingio.operation", name)
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.path", path)
+
+	start := time.Now()
+	meta, attempts, err := c.doWithRetry(ctx, service, name, method, path, body, dest, cfg)
+	elapsed := time.Since(start)
+
+	m := RequestMetrics{
+		Service:   service,
+		Operation: name,
+		Method:    method,
+		Duration:  elapsed,
+		Retries:   max(attempts-1, 0),
+		ErrorType: errorType(err),
+	}
+	span.SetAttribute("billingio.retries", m.Retries)
+	if meta != nil {
+		meta.Latency = elapsed
+		if cfg.response != nil {
+			*cfg.response = *meta
+		}
+		m.StatusCode = meta.StatusCode
+		span.SetAttribute("http.status_code", meta.StatusCode)
+		span.SetAttribute("billingio.request_id", meta.RequestID)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+	if c.metrics != nil {
+		c.metrics.RecordRequest(ctx, m)
+	}
+
+	return err
+}
+
+// doWithRetry sends the request, retrying failed attempts according to the
+// client's RetryPolicy. The request body is marshalled once and replayed on
+// every attempt. It returns the metadata of the last response received.
+func (c *Client) doWithRetry(ctx context.Context, service, name, method, path string, body any, dest any, cfg *requestConfig) (meta *Response, attempts int, err error) {
+	var buf []byte
 	if body != nil {
-		buf, err := json.Marshal(body)
+		buf, err = json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("billingio: failed to marshal request body: %w", err)
+			return nil, 0, fmt.Errorf("billingio: failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(buf)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
-	if err != nil {
-		return fmt.Errorf("billingio: failed to create request: %w", err)
+	// Every POST carries an idempotency key so that retries can never apply
+	// the same mutation twice. The key is generated once per logical call
+	// and reused on every attempt.
+	if method == http.MethodPost && cfg.header.Get(idempotencyKeyHeader) == "" {
+		cfg.header.Set(idempotencyKeyHeader, newIdempotencyKey())
 	}
+	idempotencyKey := cfg.header.Get(idempotencyKeyHeader)
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("User-Agent", c.userAgent)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	maxRetries := c.retryPolicy.MaxRetries
+	if cfg.maxRetries != nil {
+		maxRetries = *cfg.maxRetries
 	}
-	for k, v := range headers {
-		req.Header.Set(k, v)
+
+	apiKey := c.apiKey
+	if cfg.apiKey != "" {
+		apiKey = cfg.apiKey
 	}
 
-	resp, err := c.httpClient.Do(req)
+	for attempt := 0; ; attempt++ {
+		req := &Request{
+			Service:        service,
+			Operation:      name,
+			Method:         method,
+			Path:           path,
+			IdempotencyKey: idempotencyKey,
+			Attempt:        attempt + 1,
+			Header:         make(http.Header),
+			Params:         body,
+			Result:         dest,
+			body:           buf,
+		}
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		req.Header.Set("User-Agent", c.userAgent)
+		if buf != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range cfg.header {
+			req.Header[k] = append([]string(nil), v...)
+		}
+
+		attempts = attempt + 1
+		resp, err := c.handler(ctx, req)
+		if resp != nil {
+			resp.Attempts = attempts
+			meta = resp
+		}
+		if err == nil {
+			return meta, attempts, nil
+		}
+		if attempt >= maxRetries || !shouldRetry(ctx, method, idempotencyKey != "", err) {
+			return meta, attempts, err
+		}
+		delay, ok := c.retryPolicy.delay(attempt+1, err)
+		if !ok {
+			return meta, attempts, err
+		}
+		c.logRetry(ctx, req, delay, err)
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return meta, attempts, err
+		}
+	}
+}
+
+// send performs a single HTTP round trip for req. It is the innermost
+// Handler of the middleware chain. The returned *Response is nil if no
+// response was received.
+func (c *Client) send(ctx context.Context, req *Request) (meta *Response, err error) {
+	start := time.Now()
+	var respBody []byte
+	defer func() {
+		c.logAttempt(ctx, req, meta, time.Since(start), respBody, err)
+	}()
+
+	u := c.baseURL + req.Path
+
+	var reqBody io.Reader
+	if req.body != nil {
+		reqBody = bytes.NewReader(req.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, u, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("billingio: failed to create request: %w", err)
+	}
+	httpReq.Header = req.Header
+
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("billingio: request failed: %w", err)
+		return nil, &requestError{err: err}
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	meta = newResponse(resp)
+
+	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("billingio: failed to read response body: %w", err)
+		return meta, &requestError{err: fmt.Errorf("failed to read response body: %w", err)}
 	}
 
+	c.recordRateLimit(meta.RateLimit)
+
 	if resp.StatusCode >= 400 {
-		return parseAPIError(resp.StatusCode, respBody)
+		return meta, parseAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
-	if dest != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, dest); err != nil {
-			return fmt.Errorf("billingio: failed to decode response: %w", err)
+	if req.Result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, req.Result); err != nil {
+			return meta, fmt.Errorf("billingio: failed to decode response: %w", err)
 		}
 	}
 
-	return nil
+	return meta, nil
 }
 
 // get is a convenience wrapper for GET requests.
-func (c *Client) get(ctx context.Context, path string, dest any) error {
-	return c.do(ctx, http.MethodGet, path, nil, dest, nil)
+func (c *Client) get(ctx context.Context, op, path string, dest any, opts ...RequestOption) error {
+	return c.do(ctx, op, http.MethodGet, path, nil, dest, opts)
 }
 
 // post is a convenience wrapper for POST requests.
-func (c *Client) post(ctx context.Context, path string, body any, dest any, headers map[string]string) error {
-	return c.do(ctx, http.MethodPost, path, body, dest, headers)
+func (c *Client) post(ctx context.Context, op, path string, body any, dest any, opts ...RequestOption) error {
+	return c.do(ctx, op, http.MethodPost, path, body, dest, opts)
 }
 
 // patch is a convenience wrapper for PATCH requests.
-func (c *Client) patch(ctx context.Context, path string, body any, dest any) error {
-	return c.do(ctx, http.MethodPatch, path, body, dest, nil)
+func (c *Client) patch(ctx context.Context, op, path string, body any, dest any, opts ...RequestOption) error {
+	return c.do(ctx, op, http.MethodPatch, path, body, dest, opts)
 }
 
 // del is a convenience wrapper for DELETE requests.
-func (c *Client) del(ctx context.Context, path string) error {
-	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+func (c *Client) del(ctx context.Context, op, path string, opts ...RequestOption) error {
+	return c.do(ctx, op, http.MethodDelete, path, nil, nil, opts)
 }
 
-// parseAPIError decodes an error response body into an *Error.
-func parseAPIError(statusCode int, body []byte) error {
+// parseAPIError decodes an error response body into an *Error, attaching
+// the retry and rate-limit information carried by the response headers.
+func parseAPIError(statusCode int, header http.Header, body []byte) error {
 	var errResp errorResponse
 	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Err == nil {
-		return &Error{
-			Type:       "internal_error",
-			Code:       "unknown",
-			StatusCode: statusCode,
-			Message:    fmt.Sprintf("unexpected error (HTTP %d): %s", statusCode, string(body)),
+		errResp.Err = &Error{
+			Type:    "internal_error",
+			Code:    "unknown",
+			Message: fmt.Sprintf("unexpected error (HTTP %d): %s", statusCode, string(body)),
 		}
 	}
 	errResp.Err.StatusCode = statusCode
+	errResp.Err.RequestID = header.Get(requestIDHeader)
+	errResp.Err.RetryAfter = parseRetryAfter(header, time.Now())
+	errResp.Err.RateLimit = parseRateLimit(header)
 	return errResp.Err
 }
 
@@ -229,3 +384,11 @@ func strOrEmpty(v *string) string {
 	}
 	return *v
 }
+
+// timeToString formats a *time.Time as RFC 3339 in UTC, or returns "".
+func timeToString(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+	return v.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,67 @@
+// Package billingotel adapts OpenTelemetry tracing to the billingio.Tracer
+// interface.
+//
+//	client := billingio.New("sk_live_...",
+//	    billingio.WithTracer(billingotel.NewTracer(otel.GetTracerProvider())),
+//	)
+package billingotel
+
+import (
+	"context"
+	"fmt"
+
+	billingio "github.com/billing-io/billing-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this package.
+const instrumentationName = "github.com/billing-io/billing-go"
+
+// Tracer implements billingio.Tracer on top of an OpenTelemetry tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer that creates client spans from tp.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// Start implements billingio.Tracer.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, billingio.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &spanAdapter{span: span}
+}
+
+// spanAdapter implements billingio.Span on top of an OpenTelemetry span.
+type spanAdapter struct {
+	span trace.Span
+}
+
+func (s *spanAdapter) SetAttribute(key string, value any) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case int64:
+		s.span.SetAttributes(attribute.Int64(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	case float64:
+		s.span.SetAttributes(attribute.Float64(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+func (s *spanAdapter) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *spanAdapter) End() {
+	s.span.End()
+}
@@ -0,0 +1,14 @@
+module github.com/billing-io/billing-go/billingotel
+
+go 1.21
+
+require (
+	github.com/billing-io/billing-go v0.2.0
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+// Builds inside this repository use the local core module. The replace is
+// ignored when the adapter is required by another module, which resolves the
+// core version above.
+replace github.com/billing-io/billing-go => ../
@@ -0,0 +1,84 @@
+// Package billingprom records billing.io client metrics with Prometheus.
+//
+//	metrics, err := billingprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := billingio.New("sk_live_...", billingio.WithMetrics(metrics))
+package billingprom
+
+import (
+	"context"
+	"strconv"
+
+	billingio "github.com/billing-io/billing-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics implements billingio.MetricsRecorder with Prometheus collectors.
+type Metrics struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	retries      *prometheus.CounterVec
+	pages        *prometheus.CounterVec
+	pageDuration *prometheus.HistogramVec
+}
+
+// New creates the collectors and registers them with reg.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "billingio",
+			Name:      "requests_total",
+			Help:      "API calls made by the billing.io client.",
+		}, []string{"service", "operation", "method", "status", "error_type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "billingio",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of billing.io API calls, including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "billingio",
+			Name:      "request_retries_total",
+			Help:      "Retried attempts of billing.io API calls.",
+		}, []string{"service", "operation"}),
+		pages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "billingio",
+			Name:      "page_fetches_total",
+			Help:      "Pages fetched by billing.io auto-pagination.",
+		}, []string{"service", "error_type"}),
+		pageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "billingio",
+			Name:      "page_fetch_duration_seconds",
+			Help:      "Duration of billing.io auto-pagination page fetches.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.retries, m.pages, m.pageDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// RecordRequest implements billingio.MetricsRecorder.
+func (m *Metrics) RecordRequest(_ context.Context, r billingio.RequestMetrics) {
+	status := ""
+	if r.StatusCode != 0 {
+		status = strconv.Itoa(r.StatusCode)
+	}
+	m.requests.WithLabelValues(r.Service, r.Operation, r.Method, status, r.ErrorType).Inc()
+	m.duration.WithLabelValues(r.Service, r.Operation).Observe(r.Duration.Seconds())
+	if r.Retries > 0 {
+		m.retries.WithLabelValues(r.Service, r.Operation).Add(float64(r.Retries))
+	}
+}
+
+// RecordPageFetch implements billingio.MetricsRecorder.
+func (m *Metrics) RecordPageFetch(_ context.Context, p billingio.PageMetrics) {
+	m.pages.WithLabelValues(p.Service, p.ErrorType).Inc()
+	m.pageDuration.WithLabelValues(p.Service).Observe(p.Duration.Seconds())
+}
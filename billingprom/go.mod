@@ -0,0 +1,23 @@
+module github.com/billing-io/billing-go/billingprom
+
+go 1.21
+
+require (
+	github.com/billing-io/billing-go v0.2.0
+	github.com/prometheus/client_golang v1.19.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	google.golang.org/protobuf v1.32.0 // indirect
+)
+
+// Builds inside this repository use the local core module. The replace is
+// ignored when the adapter is required by another module, which resolves the
+// core version above.
+replace github.com/billing-io/billing-go => ../
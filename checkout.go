@@ -12,17 +12,19 @@ type CheckoutService struct {
 
 // Create creates a new payment checkout.
 //
-// If params.IdempotencyKey is set it is sent as the Idempotency-Key header.
-func (s *CheckoutService) Create(ctx context.Context, params *CreateCheckoutParams) (*Checkout, error) {
-	var headers map[string]string
-	if params.IdempotencyKey != "" {
-		headers = map[string]string{
-			"Idempotency-Key": params.IdempotencyKey,
-		}
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *CheckoutService) Create(ctx context.Context, params *CreateCheckoutParams, opts ...RequestOption) (*Checkout, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
 	}
 
 	var checkout Checkout
-	err := s.client.post(ctx, "/checkouts", params, &checkout, headers)
+	err := s.client.post(ctx, "Checkouts.Create", "/checkouts", params, &checkout, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +32,11 @@ func (s *CheckoutService) Create(ctx context.Context, params *CreateCheckoutPara
 }
 
 // List returns a paginated list of checkouts, newest first.
-func (s *CheckoutService) List(ctx context.Context, params *ListCheckoutsParams) (*CheckoutList, error) {
+func (s *CheckoutService) List(ctx context.Context, params *ListCheckoutsParams, opts ...RequestOption) (*CheckoutList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -42,7 +48,7 @@ func (s *CheckoutService) List(ctx context.Context, params *ListCheckoutsParams)
 	path := addQueryParams("/checkouts", qp)
 
 	var list CheckoutList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Checkouts.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +56,9 @@ func (s *CheckoutService) List(ctx context.Context, params *ListCheckoutsParams)
 }
 
 // Get retrieves a single checkout by ID.
-func (s *CheckoutService) Get(ctx context.Context, checkoutID string) (*Checkout, error) {
+func (s *CheckoutService) Get(ctx context.Context, checkoutID string, opts ...RequestOption) (*Checkout, error) {
 	var checkout Checkout
-	err := s.client.get(ctx, fmt.Sprintf("/checkouts/%s", checkoutID), &checkout)
+	err := s.client.get(ctx, "Checkouts.Get", fmt.Sprintf("/checkouts/%s", checkoutID), &checkout, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +66,9 @@ func (s *CheckoutService) Get(ctx context.Context, checkoutID string) (*Checkout
 }
 
 // GetStatus returns the lightweight polling status of a checkout.
-func (s *CheckoutService) GetStatus(ctx context.Context, checkoutID string) (*CheckoutStatusResponse, error) {
+func (s *CheckoutService) GetStatus(ctx context.Context, checkoutID string, opts ...RequestOption) (*CheckoutStatusResponse, error) {
 	var status CheckoutStatusResponse
-	err := s.client.get(ctx, fmt.Sprintf("/checkouts/%s/status", checkoutID), &status)
+	err := s.client.get(ctx, "Checkouts.GetStatus", fmt.Sprintf("/checkouts/%s/status", checkoutID), &status, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,15 +77,15 @@ func (s *CheckoutService) GetStatus(ctx context.Context, checkoutID string) (*Ch
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of checkouts. See Iter for usage details.
-func (s *CheckoutService) ListAutoPaginate(ctx context.Context, params *ListCheckoutsParams) *Iter[Checkout] {
+func (s *CheckoutService) ListAutoPaginate(ctx context.Context, params *ListCheckoutsParams, opts ...RequestOption) *Iter[Checkout] {
 	if params == nil {
 		params = &ListCheckoutsParams{}
 	}
 	p := *params // shallow copy so we can mutate cursor
 
-	return newIter(func(cursor *string) ([]Checkout, bool, *string, error) {
+	return newIter(ctx, s.client, "Checkouts", func(ctx context.Context, cursor *string) ([]Checkout, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -0,0 +1,109 @@
+package billingio
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// defaultPollInterval is used when the server does not advise one.
+	defaultPollInterval = 3 * time.Second
+
+	// defaultExpiryGrace is how long Wait keeps polling an unpaid checkout
+	// past its ExpiresAt for the server to report it expired.
+	defaultExpiryGrace = 30 * time.Second
+)
+
+// ErrCheckoutExpired is returned by CheckoutService.Wait when an unpaid
+// checkout is past its ExpiresAt but the API has not yet reported it
+// expired.
+var ErrCheckoutExpired = errors.New("billingio: checkout expired without payment")
+
+// IsTerminal reports whether s is a final checkout status: confirmed,
+// expired or failed.
+func (s CheckoutStatus) IsTerminal() bool {
+	switch s {
+	case CheckoutStatusConfirmed, CheckoutStatusExpired, CheckoutStatusFailed:
+		return true
+	}
+	return false
+}
+
+// WaitOptions configures CheckoutService.Wait.
+type WaitOptions struct {
+	// OnUpdate, if set, is called from Wait's goroutine with the first
+	// status observed and again whenever the status or confirmation count
+	// changes, including the final status.
+	OnUpdate func(*CheckoutStatusResponse)
+
+	// PollInterval is used when the API does not return
+	// PollingIntervalMs. Defaults to 3 seconds.
+	PollInterval time.Duration
+
+	// ExpiryGrace is how long to keep polling an unpaid checkout after its
+	// ExpiresAt before giving up with ErrCheckoutExpired. Defaults to 30
+	// seconds. Checkouts with a detected payment are always followed to a
+	// terminal status.
+	ExpiryGrace time.Duration
+}
+
+// Wait polls the checkout's status until it reaches a terminal status
+// (confirmed, expired or failed) and returns that status. Polls are spaced
+// by the PollingIntervalMs advised by the API.
+//
+// Wait returns early with the last observed status and an error if ctx is
+// done, a request fails, or the checkout is unpaid past its ExpiresAt (see
+// ErrCheckoutExpired). opts may be nil.
+//
+//	status, err := client.Checkouts.Wait(ctx, checkout.CheckoutID, &billingio.WaitOptions{
+//	    OnUpdate: func(s *billingio.CheckoutStatusResponse) {
+//	        log.Printf("%s: %d/%d confirmations", s.Status, s.Confirmations, s.RequiredConfirmations)
+//	    },
+//	})
+func (s *CheckoutService) Wait(ctx context.Context, checkoutID string, opts *WaitOptions, reqOpts ...RequestOption) (*CheckoutStatusResponse, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	fallback := opts.PollInterval
+	if fallback <= 0 {
+		fallback = defaultPollInterval
+	}
+	grace := opts.ExpiryGrace
+	if grace <= 0 {
+		grace = defaultExpiryGrace
+	}
+
+	checkout, err := s.Get(ctx, checkoutID, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	deadline := checkout.ExpiresAt.Add(grace)
+
+	var last *CheckoutStatusResponse
+	for {
+		status, err := s.GetStatus(ctx, checkoutID, reqOpts...)
+		if err != nil {
+			return last, err
+		}
+		if opts.OnUpdate != nil && (last == nil || status.Status != last.Status || status.Confirmations != last.Confirmations) {
+			opts.OnUpdate(status)
+		}
+		last = status
+
+		if status.Status.IsTerminal() {
+			return status, nil
+		}
+		if status.Status == CheckoutStatusPending && !checkout.ExpiresAt.IsZero() && time.Now().After(deadline) {
+			return status, ErrCheckoutExpired
+		}
+
+		interval := fallback
+		if status.PollingIntervalMs > 0 {
+			interval = time.Duration(status.PollingIntervalMs) * time.Millisecond
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return status, err
+		}
+	}
+}
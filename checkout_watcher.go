@@ -0,0 +1,417 @@
+package billingio
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWatcherConcurrency = 4
+	defaultWatcherRPS         = 5
+	defaultWatcherBuffer      = 100
+)
+
+// WatcherOptions configures a CheckoutWatcher.
+type WatcherOptions struct {
+	// Concurrency is the maximum number of status requests in flight.
+	// Defaults to 4.
+	Concurrency int
+
+	// RequestsPerSecond caps the rate of status requests across all
+	// watched checkouts. Defaults to 5.
+	RequestsPerSecond float64
+
+	// PollInterval is the delay between polls of a checkout when the API
+	// does not return PollingIntervalMs, and after a failed poll. Defaults
+	// to 3 seconds.
+	PollInterval time.Duration
+
+	// BufferSize is the capacity of the Updates channel. Defaults to 100.
+	BufferSize int
+}
+
+// CheckoutUpdate is emitted by a CheckoutWatcher when a watched checkout
+// changes.
+type CheckoutUpdate struct {
+	// CheckoutID identifies the checkout.
+	CheckoutID string
+
+	// Status is the latest observed status. It is nil if Err is set.
+	Status *CheckoutStatusResponse
+
+	// Err is set if the checkout can no longer be polled (e.g. it was not
+	// found). The watcher stops tracking the checkout in that case.
+	Err error
+}
+
+// CheckoutWatcher polls the status of many checkouts within a shared
+// concurrency and request-rate budget, and emits a CheckoutUpdate whenever a
+// checkout's status or confirmation count changes. A checkout is dropped once
+// it reaches a terminal status.
+//
+// If the consumer falls behind, pending updates for the same checkout are
+// coalesced so that only the latest is delivered.
+//
+//	w := client.Checkouts.NewWatcher(&billingio.WatcherOptions{RequestsPerSecond: 10})
+//	w.Add(ids...)
+//	go w.Run(ctx)
+//	for u := range w.Updates() {
+//	    ...
+//	}
+type CheckoutWatcher struct {
+	svc          *CheckoutService
+	concurrency  int
+	pollInterval time.Duration
+	limiter      *pollLimiter
+	updates      chan CheckoutUpdate
+
+	mu      sync.Mutex
+	watched map[string]*watchEntry
+	queue   watchQueue
+	wake    chan struct{}
+
+	pendingMu sync.Mutex
+	pending   map[string]CheckoutUpdate
+	order     []string
+	notify    chan struct{}
+}
+
+// NewWatcher returns a CheckoutWatcher using s. opts may be nil.
+func (s *CheckoutService) NewWatcher(opts *WatcherOptions) *CheckoutWatcher {
+	if opts == nil {
+		opts = &WatcherOptions{}
+	}
+	w := &CheckoutWatcher{
+		svc:          s,
+		concurrency:  opts.Concurrency,
+		pollInterval: opts.PollInterval,
+		watched:      make(map[string]*watchEntry),
+		wake:         make(chan struct{}, 1),
+		pending:      make(map[string]CheckoutUpdate),
+		notify:       make(chan struct{}, 1),
+	}
+	if w.concurrency <= 0 {
+		w.concurrency = defaultWatcherConcurrency
+	}
+	if w.pollInterval <= 0 {
+		w.pollInterval = defaultPollInterval
+	}
+	rps := opts.RequestsPerSecond
+	if rps <= 0 {
+		rps = defaultWatcherRPS
+	}
+	w.limiter = &pollLimiter{interval: time.Duration(float64(time.Second) / rps)}
+	buffer := opts.BufferSize
+	if buffer <= 0 {
+		buffer = defaultWatcherBuffer
+	}
+	w.updates = make(chan CheckoutUpdate, buffer)
+	return w
+}
+
+// Add starts watching the given checkouts. IDs already being watched are
+// ignored. Add may be called at any time, including while Run is active.
+func (w *CheckoutWatcher) Add(checkoutIDs ...string) {
+	w.mu.Lock()
+	now := time.Now()
+	for _, id := range checkoutIDs {
+		if _, ok := w.watched[id]; ok {
+			continue
+		}
+		e := &watchEntry{id: id, next: now}
+		w.watched[id] = e
+		heap.Push(&w.queue, e)
+	}
+	w.mu.Unlock()
+	signal(w.wake)
+}
+
+// Remove stops watching the given checkouts.
+func (w *CheckoutWatcher) Remove(checkoutIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range checkoutIDs {
+		w.drop(id)
+	}
+}
+
+// Len returns the number of checkouts being watched.
+func (w *CheckoutWatcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.watched)
+}
+
+// Updates returns the channel on which updates are delivered. It is closed
+// when Run returns.
+func (w *CheckoutWatcher) Updates() <-chan CheckoutUpdate {
+	return w.updates
+}
+
+// Run polls the watched checkouts until ctx is done, then waits for
+// in-flight requests, delivers the updates still pending, closes the Updates
+// channel and returns ctx.Err(). Keep receiving from Updates until it is
+// closed, or Run does not return. Run must be called at most once.
+func (w *CheckoutWatcher) Run(ctx context.Context) error {
+	stop := make(chan struct{})
+	delivered := make(chan struct{})
+	go func() {
+		defer close(delivered)
+		w.deliver(stop)
+	}()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, w.concurrency)
+	for ctx.Err() == nil {
+		w.mu.Lock()
+		queued := len(w.queue) > 0
+		var wait time.Duration
+		if queued {
+			wait = time.Until(w.queue[0].next)
+		}
+		w.mu.Unlock()
+
+		if !queued || wait > 0 {
+			var timeout <-chan time.Time
+			var t *time.Timer
+			if queued {
+				t = time.NewTimer(wait)
+				timeout = t.C
+			}
+			select {
+			case <-ctx.Done():
+			case <-w.wake:
+			case <-timeout:
+			}
+			if t != nil {
+				t.Stop()
+			}
+			continue
+		}
+
+		if err := w.limiter.wait(ctx); err != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		w.mu.Lock()
+		var e *watchEntry
+		if len(w.queue) > 0 && !w.queue[0].next.After(time.Now()) {
+			e = heap.Pop(&w.queue).(*watchEntry)
+		}
+		w.mu.Unlock()
+		if e == nil {
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			w.poll(ctx, e)
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	<-delivered
+	close(w.updates)
+	return ctx.Err()
+}
+
+// poll fetches the status of e's checkout and reschedules or drops it.
+func (w *CheckoutWatcher) poll(ctx context.Context, e *watchEntry) {
+	// The watcher reschedules failed polls itself so that retries stay
+	// within the shared budget.
+	status, err := w.svc.GetStatus(ctx, e.id, WithRequestMaxRetries(0))
+	if ctx.Err() != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watched[e.id] != e {
+		return // removed while in flight
+	}
+
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+			apiErr.StatusCode != 409 && apiErr.StatusCode != 429 {
+			w.drop(e.id)
+			w.emit(CheckoutUpdate{CheckoutID: e.id, Err: err})
+			return
+		}
+		delay := w.pollInterval
+		if apiErr != nil {
+			if d := serverRequestedDelay(apiErr, time.Now()); d > 0 {
+				w.limiter.pause(d)
+				delay = max(delay, d)
+			}
+		}
+		w.schedule(e, delay)
+		return
+	}
+
+	if e.last == nil || status.Status != e.last.Status || status.Confirmations != e.last.Confirmations {
+		w.emit(CheckoutUpdate{CheckoutID: e.id, Status: status})
+	}
+	e.last = status
+
+	if status.Status.IsTerminal() {
+		w.drop(e.id)
+		return
+	}
+
+	interval := w.pollInterval
+	if status.PollingIntervalMs > 0 {
+		interval = time.Duration(status.PollingIntervalMs) * time.Millisecond
+	}
+	w.schedule(e, interval)
+}
+
+// schedule queues e to be polled after d and wakes Run, which may be waiting
+// with every checkout in flight or for a later poll. w.mu must be held.
+func (w *CheckoutWatcher) schedule(e *watchEntry, d time.Duration) {
+	e.next = time.Now().Add(d)
+	heap.Push(&w.queue, e)
+	signal(w.wake)
+}
+
+// drop stops watching id. w.mu must be held.
+func (w *CheckoutWatcher) drop(id string) {
+	e, ok := w.watched[id]
+	if !ok {
+		return
+	}
+	delete(w.watched, id)
+	if e.index >= 0 {
+		heap.Remove(&w.queue, e.index)
+	}
+}
+
+// emit queues u for delivery, replacing any undelivered update for the same
+// checkout.
+func (w *CheckoutWatcher) emit(u CheckoutUpdate) {
+	w.pendingMu.Lock()
+	if _, ok := w.pending[u.CheckoutID]; !ok {
+		w.order = append(w.order, u.CheckoutID)
+	}
+	w.pending[u.CheckoutID] = u
+	w.pendingMu.Unlock()
+	signal(w.notify)
+}
+
+// deliver sends queued updates to the Updates channel until stop is closed
+// and no updates are pending.
+func (w *CheckoutWatcher) deliver(stop <-chan struct{}) {
+	for {
+		w.pendingMu.Lock()
+		var (
+			u  CheckoutUpdate
+			ok bool
+		)
+		if len(w.order) > 0 {
+			id := w.order[0]
+			w.order = w.order[1:]
+			u, ok = w.pending[id]
+			delete(w.pending, id)
+		}
+		w.pendingMu.Unlock()
+
+		if !ok {
+			select {
+			case <-stop:
+				return
+			case <-w.notify:
+			}
+			continue
+		}
+		w.updates <- u
+	}
+}
+
+// signal performs a non-blocking send on a wake-up channel of capacity 1.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// pollLimiter spaces requests evenly at a fixed rate.
+type pollLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request may be sent.
+func (l *pollLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	d := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	if d <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, d)
+}
+
+// pause holds back all requests for d, e.g. after a rate-limit response.
+func (l *pollLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// watchEntry is a watched checkout.
+type watchEntry struct {
+	id    string
+	next  time.Time
+	last  *CheckoutStatusResponse
+	index int // position in watchQueue, or -1 if not queued
+}
+
+// watchQueue is a min-heap of entries ordered by next poll time.
+type watchQueue []*watchEntry
+
+func (q watchQueue) Len() int           { return len(q) }
+func (q watchQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+
+func (q watchQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *watchQueue) Push(x any) {
+	e := x.(*watchEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *watchQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*q = old[:len(old)-1]
+	return e
+}
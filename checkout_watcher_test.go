@@ -0,0 +1,112 @@
+package billingio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statusServer serves checkout statuses that advance from pending to
+// confirmed after a number of polls.
+type statusServer struct {
+	mu       sync.Mutex
+	polls    map[string]int
+	confirms int // poll at which a checkout is confirmed
+}
+
+func (s *statusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/checkouts/"), "/status")
+	s.mu.Lock()
+	s.polls[id]++
+	n := s.polls[id]
+	s.mu.Unlock()
+
+	status, confirmations := "pending", 0
+	switch {
+	case n >= s.confirms:
+		status, confirmations = "confirmed", 2
+	case n > 1:
+		status, confirmations = "confirming", 1
+	}
+	fmt.Fprintf(w, `{"checkout_id":%q,"status":%q,"confirmations":%d,"required_confirmations":2,"polling_interval_ms":50}`,
+		id, status, confirmations)
+}
+
+func TestWatcherReachesTerminalStatus(t *testing.T) {
+	srv := &statusServer{polls: make(map[string]int), confirms: 4}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := New("sk_test", WithBaseURL(ts.URL))
+
+	// A single checkout is in flight whenever Run looks for work, so Run
+	// must be woken when it is rescheduled.
+	w := client.Checkouts.NewWatcher(&WatcherOptions{RequestsPerSecond: 100})
+	w.Add("co_1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	got := map[string][]CheckoutStatus{}
+	for u := range w.Updates() {
+		if u.Err != nil {
+			t.Fatalf("update for %s: %v", u.CheckoutID, u.Err)
+		}
+		got[u.CheckoutID] = append(got[u.CheckoutID], u.Status.Status)
+		if u.Status.Status == CheckoutStatusConfirmed {
+			cancel()
+		}
+	}
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+
+	want := []CheckoutStatus{CheckoutStatusPending, CheckoutStatusConfirming, CheckoutStatusConfirmed}
+	if fmt.Sprint(got["co_1"]) != fmt.Sprint(want) {
+		t.Errorf("updates = %v, want %v", got["co_1"], want)
+	}
+	if n := w.Len(); n != 0 {
+		t.Errorf("Len = %d after terminal statuses, want 0", n)
+	}
+}
+
+func TestWatcherDeliversPendingOnStop(t *testing.T) {
+	srv := &statusServer{polls: make(map[string]int), confirms: 1}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := New("sk_test", WithBaseURL(ts.URL))
+
+	w := client.Checkouts.NewWatcher(&WatcherOptions{RequestsPerSecond: 100, BufferSize: 1})
+	w.Add("co_1", "co_2")
+
+	// Stop the watcher before anything reads Updates. One terminal update
+	// fills the buffer and the other is left pending; both must be
+	// delivered before the channel is closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	for w.Len() > 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	var got []CheckoutUpdate
+	for u := range w.Updates() {
+		got = append(got, u)
+	}
+	<-done
+	if len(got) != 2 {
+		t.Fatalf("got %d updates, want 2", len(got))
+	}
+	for _, u := range got {
+		if u.Status == nil || u.Status.Status != CheckoutStatusConfirmed {
+			t.Errorf("update = %+v, want confirmed", u)
+		}
+	}
+}
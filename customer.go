@@ -11,9 +11,20 @@ type CustomerService struct {
 }
 
 // Create creates a new customer.
-func (s *CustomerService) Create(ctx context.Context, params *CreateCustomerParams) (*Customer, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *CustomerService) Create(ctx context.Context, params *CreateCustomerParams, opts ...RequestOption) (*Customer, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var customer Customer
-	err := s.client.post(ctx, "/customers", params, &customer, nil)
+	err := s.client.post(ctx, "Customers.Create", "/customers", params, &customer, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +32,11 @@ func (s *CustomerService) Create(ctx context.Context, params *CreateCustomerPara
 }
 
 // List returns a paginated list of customers.
-func (s *CustomerService) List(ctx context.Context, params *ListCustomersParams) (*CustomerList, error) {
+func (s *CustomerService) List(ctx context.Context, params *ListCustomersParams, opts ...RequestOption) (*CustomerList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -33,7 +48,7 @@ func (s *CustomerService) List(ctx context.Context, params *ListCustomersParams)
 	path := addQueryParams("/customers", qp)
 
 	var list CustomerList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Customers.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +56,9 @@ func (s *CustomerService) List(ctx context.Context, params *ListCustomersParams)
 }
 
 // Get retrieves a single customer by ID.
-func (s *CustomerService) Get(ctx context.Context, customerID string) (*Customer, error) {
+func (s *CustomerService) Get(ctx context.Context, customerID string, opts ...RequestOption) (*Customer, error) {
 	var customer Customer
-	err := s.client.get(ctx, fmt.Sprintf("/customers/%s", customerID), &customer)
+	err := s.client.get(ctx, "Customers.Get", fmt.Sprintf("/customers/%s", customerID), &customer, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,9 +66,13 @@ func (s *CustomerService) Get(ctx context.Context, customerID string) (*Customer
 }
 
 // Update updates an existing customer.
-func (s *CustomerService) Update(ctx context.Context, customerID string, params *UpdateCustomerParams) (*Customer, error) {
+func (s *CustomerService) Update(ctx context.Context, customerID string, params *UpdateCustomerParams, opts ...RequestOption) (*Customer, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var customer Customer
-	err := s.client.patch(ctx, fmt.Sprintf("/customers/%s", customerID), params, &customer)
+	err := s.client.patch(ctx, "Customers.Update", fmt.Sprintf("/customers/%s", customerID), params, &customer, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,15 +81,15 @@ func (s *CustomerService) Update(ctx context.Context, customerID string, params
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of customers. See Iter for usage details.
-func (s *CustomerService) ListAutoPaginate(ctx context.Context, params *ListCustomersParams) *Iter[Customer] {
+func (s *CustomerService) ListAutoPaginate(ctx context.Context, params *ListCustomersParams, opts ...RequestOption) *Iter[Customer] {
 	if params == nil {
 		params = &ListCustomersParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Customer, bool, *string, error) {
+	return newIter(ctx, s.client, "Customers", func(ctx context.Context, cursor *string) ([]Customer, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -11,7 +11,11 @@ type EntitlementService struct {
 }
 
 // List returns a paginated list of entitlements.
-func (s *EntitlementService) List(ctx context.Context, params *ListEntitlementsParams) (*EntitlementList, error) {
+func (s *EntitlementService) List(ctx context.Context, params *ListEntitlementsParams, opts ...RequestOption) (*EntitlementList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -22,7 +26,7 @@ func (s *EntitlementService) List(ctx context.Context, params *ListEntitlementsP
 	path := addQueryParams("/subscriptions/entitlements", qp)
 
 	var list EntitlementList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Entitlements.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -30,9 +34,20 @@ func (s *EntitlementService) List(ctx context.Context, params *ListEntitlementsP
 }
 
 // Create creates a new entitlement.
-func (s *EntitlementService) Create(ctx context.Context, params *CreateEntitlementParams) (*Entitlement, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *EntitlementService) Create(ctx context.Context, params *CreateEntitlementParams, opts ...RequestOption) (*Entitlement, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var ent Entitlement
-	err := s.client.post(ctx, "/subscriptions/entitlements", params, &ent, nil)
+	err := s.client.post(ctx, "Entitlements.Create", "/subscriptions/entitlements", params, &ent, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,9 +55,13 @@ func (s *EntitlementService) Create(ctx context.Context, params *CreateEntitleme
 }
 
 // Update updates an existing entitlement.
-func (s *EntitlementService) Update(ctx context.Context, entitlementID string, params *UpdateEntitlementParams) (*Entitlement, error) {
+func (s *EntitlementService) Update(ctx context.Context, entitlementID string, params *UpdateEntitlementParams, opts ...RequestOption) (*Entitlement, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var ent Entitlement
-	err := s.client.patch(ctx, fmt.Sprintf("/subscriptions/entitlements/%s", entitlementID), params, &ent)
+	err := s.client.patch(ctx, "Entitlements.Update", fmt.Sprintf("/subscriptions/entitlements/%s", entitlementID), params, &ent, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,12 +69,16 @@ func (s *EntitlementService) Update(ctx context.Context, entitlementID string, p
 }
 
 // Delete removes an entitlement.
-func (s *EntitlementService) Delete(ctx context.Context, entitlementID string) error {
-	return s.client.del(ctx, fmt.Sprintf("/subscriptions/entitlements/%s", entitlementID))
+func (s *EntitlementService) Delete(ctx context.Context, entitlementID string, opts ...RequestOption) error {
+	return s.client.del(ctx, "Entitlements.Delete", fmt.Sprintf("/subscriptions/entitlements/%s", entitlementID), opts...)
 }
 
 // Check checks whether a customer is entitled to a specific feature.
-func (s *EntitlementService) Check(ctx context.Context, params *CheckEntitlementParams) (*EntitlementCheckResponse, error) {
+func (s *EntitlementService) Check(ctx context.Context, params *CheckEntitlementParams, opts ...RequestOption) (*EntitlementCheckResponse, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["customer_id"] = params.CustomerID
@@ -64,7 +87,7 @@ func (s *EntitlementService) Check(ctx context.Context, params *CheckEntitlement
 	path := addQueryParams("/subscriptions/entitlements/check", qp)
 
 	var resp EntitlementCheckResponse
-	err := s.client.get(ctx, path, &resp)
+	err := s.client.get(ctx, "Entitlements.Check", path, &resp, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,15 +96,15 @@ func (s *EntitlementService) Check(ctx context.Context, params *CheckEntitlement
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of entitlements. See Iter for usage details.
-func (s *EntitlementService) ListAutoPaginate(ctx context.Context, params *ListEntitlementsParams) *Iter[Entitlement] {
+func (s *EntitlementService) ListAutoPaginate(ctx context.Context, params *ListEntitlementsParams, opts ...RequestOption) *Iter[Entitlement] {
 	if params == nil {
 		params = &ListEntitlementsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Entitlement, bool, *string, error) {
+	return newIter(ctx, s.client, "Entitlements", func(ctx context.Context, cursor *string) ([]Entitlement, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -0,0 +1,428 @@
+package billingio
+
+import "fmt"
+
+// Enum values the API may add over time decode as-is, so a value this SDK
+// version does not recognise keeps the server's spelling and round-trips
+// unchanged, e.g. when an object read from the API is sent back in an update.
+// Use IsKnown to tell such values apart:
+//
+//	switch checkout.Status {
+//	case billingio.CheckoutStatusConfirmed:
+//	    // ...
+//	default:
+//	    if !checkout.Status.IsKnown() {
+//	        log.Printf("checkout %s has status %q, unknown to this SDK", checkout.CheckoutID, checkout.Status)
+//	    }
+//	}
+//
+// Every enum type has an IsKnown method and a <Type>Values function listing
+// its known values.
+
+// ChainValues returns the known Chain values.
+func ChainValues() []Chain {
+	return []Chain{ChainTron, ChainArbitrum}
+}
+
+// IsKnown reports whether v is one of the values returned by ChainValues.
+func (v Chain) IsKnown() bool {
+	return isKnownEnum(v, ChainValues())
+}
+
+// TokenValues returns the known Token values.
+func TokenValues() []Token {
+	return []Token{TokenUSDT, TokenUSDC}
+}
+
+// IsKnown reports whether v is one of the values returned by TokenValues.
+func (v Token) IsKnown() bool {
+	return isKnownEnum(v, TokenValues())
+}
+
+// CheckoutStatusValues returns the known CheckoutStatus values.
+func CheckoutStatusValues() []CheckoutStatus {
+	return []CheckoutStatus{CheckoutStatusPending, CheckoutStatusDetected, CheckoutStatusConfirming, CheckoutStatusConfirmed, CheckoutStatusExpired, CheckoutStatusFailed}
+}
+
+// IsKnown reports whether v is one of the values returned by CheckoutStatusValues.
+func (v CheckoutStatus) IsKnown() bool {
+	return isKnownEnum(v, CheckoutStatusValues())
+}
+
+// EventTypeValues returns the known EventType values.
+func EventTypeValues() []EventType {
+	return []EventType{
+		EventTypeCheckoutCreated, EventTypeCheckoutPaymentDetected, EventTypeCheckoutConfirming,
+		EventTypeCheckoutCompleted, EventTypeCheckoutExpired, EventTypeCheckoutFailed,
+		EventTypeSubscriptionCreated, EventTypeSubscriptionUpdated, EventTypeSubscriptionPaused,
+		EventTypeSubscriptionCancelled, EventTypeSubscriptionExpired,
+		EventTypeRenewalPaid, EventTypeRenewalFailed,
+		EventTypePayoutCompleted, EventTypePayoutFailed,
+		EventTypeSettlementCreated,
+	}
+}
+
+// IsKnown reports whether v is one of the values returned by EventTypeValues.
+func (v EventType) IsKnown() bool {
+	return isKnownEnum(v, EventTypeValues())
+}
+
+// WebhookEndpointStatusValues returns the known WebhookEndpointStatus values.
+func WebhookEndpointStatusValues() []WebhookEndpointStatus {
+	return []WebhookEndpointStatus{WebhookEndpointStatusActive, WebhookEndpointStatusDisabled}
+}
+
+// IsKnown reports whether v is one of the values returned by WebhookEndpointStatusValues.
+func (v WebhookEndpointStatus) IsKnown() bool {
+	return isKnownEnum(v, WebhookEndpointStatusValues())
+}
+
+// WebhookDeliveryStatusValues returns the known WebhookDeliveryStatus values.
+func WebhookDeliveryStatusValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed}
+}
+
+// IsKnown reports whether v is one of the values returned by WebhookDeliveryStatusValues.
+func (v WebhookDeliveryStatus) IsKnown() bool {
+	return isKnownEnum(v, WebhookDeliveryStatusValues())
+}
+
+// CustomerStatusValues returns the known CustomerStatus values.
+func CustomerStatusValues() []CustomerStatus {
+	return []CustomerStatus{CustomerStatusActive, CustomerStatusArchived}
+}
+
+// IsKnown reports whether v is one of the values returned by CustomerStatusValues.
+func (v CustomerStatus) IsKnown() bool {
+	return isKnownEnum(v, CustomerStatusValues())
+}
+
+// PaymentMethodTypeValues returns the known PaymentMethodType values.
+func PaymentMethodTypeValues() []PaymentMethodType {
+	return []PaymentMethodType{PaymentMethodTypeWallet}
+}
+
+// IsKnown reports whether v is one of the values returned by PaymentMethodTypeValues.
+func (v PaymentMethodType) IsKnown() bool {
+	return isKnownEnum(v, PaymentMethodTypeValues())
+}
+
+// PaymentMethodStatusValues returns the known PaymentMethodStatus values.
+func PaymentMethodStatusValues() []PaymentMethodStatus {
+	return []PaymentMethodStatus{PaymentMethodStatusActive, PaymentMethodStatusDisabled}
+}
+
+// IsKnown reports whether v is one of the values returned by PaymentMethodStatusValues.
+func (v PaymentMethodStatus) IsKnown() bool {
+	return isKnownEnum(v, PaymentMethodStatusValues())
+}
+
+// PaymentLinkStatusValues returns the known PaymentLinkStatus values.
+func PaymentLinkStatusValues() []PaymentLinkStatus {
+	return []PaymentLinkStatus{PaymentLinkStatusActive, PaymentLinkStatusInactive}
+}
+
+// IsKnown reports whether v is one of the values returned by PaymentLinkStatusValues.
+func (v PaymentLinkStatus) IsKnown() bool {
+	return isKnownEnum(v, PaymentLinkStatusValues())
+}
+
+// BillingIntervalValues returns the known BillingInterval values.
+func BillingIntervalValues() []BillingInterval {
+	return []BillingInterval{BillingIntervalWeekly, BillingIntervalMonthly, BillingIntervalYearly}
+}
+
+// IsKnown reports whether v is one of the values returned by BillingIntervalValues.
+func (v BillingInterval) IsKnown() bool {
+	return isKnownEnum(v, BillingIntervalValues())
+}
+
+// SubscriptionPlanStatusValues returns the known SubscriptionPlanStatus values.
+func SubscriptionPlanStatusValues() []SubscriptionPlanStatus {
+	return []SubscriptionPlanStatus{SubscriptionPlanStatusActive, SubscriptionPlanStatusArchived}
+}
+
+// IsKnown reports whether v is one of the values returned by SubscriptionPlanStatusValues.
+func (v SubscriptionPlanStatus) IsKnown() bool {
+	return isKnownEnum(v, SubscriptionPlanStatusValues())
+}
+
+// SubscriptionStatusValues returns the known SubscriptionStatus values.
+func SubscriptionStatusValues() []SubscriptionStatus {
+	return []SubscriptionStatus{SubscriptionStatusActive, SubscriptionStatusPaused, SubscriptionStatusCancelled, SubscriptionStatusExpired}
+}
+
+// IsKnown reports whether v is one of the values returned by SubscriptionStatusValues.
+func (v SubscriptionStatus) IsKnown() bool {
+	return isKnownEnum(v, SubscriptionStatusValues())
+}
+
+// RenewalStatusValues returns the known RenewalStatus values.
+func RenewalStatusValues() []RenewalStatus {
+	return []RenewalStatus{RenewalStatusPending, RenewalStatusPaid, RenewalStatusFailed, RenewalStatusRetrying}
+}
+
+// IsKnown reports whether v is one of the values returned by RenewalStatusValues.
+func (v RenewalStatus) IsKnown() bool {
+	return isKnownEnum(v, RenewalStatusValues())
+}
+
+// PayoutStatusValues returns the known PayoutStatus values.
+func PayoutStatusValues() []PayoutStatus {
+	return []PayoutStatus{PayoutStatusPending, PayoutStatusExecuting, PayoutStatusCompleted, PayoutStatusFailed}
+}
+
+// IsKnown reports whether v is one of the values returned by PayoutStatusValues.
+func (v PayoutStatus) IsKnown() bool {
+	return isKnownEnum(v, PayoutStatusValues())
+}
+
+// RevenueEventTypeValues returns the known RevenueEventType values.
+func RevenueEventTypeValues() []RevenueEventType {
+	return []RevenueEventType{RevenueEventTypeCharge, RevenueEventTypeRefund, RevenueEventTypeAdjustment}
+}
+
+// IsKnown reports whether v is one of the values returned by RevenueEventTypeValues.
+func (v RevenueEventType) IsKnown() bool {
+	return isKnownEnum(v, RevenueEventTypeValues())
+}
+
+// AdjustmentTypeValues returns the known AdjustmentType values.
+func AdjustmentTypeValues() []AdjustmentType {
+	return []AdjustmentType{AdjustmentTypeCredit, AdjustmentTypeDebit}
+}
+
+// IsKnown reports whether v is one of the values returned by AdjustmentTypeValues.
+func (v AdjustmentType) IsKnown() bool {
+	return isKnownEnum(v, AdjustmentTypeValues())
+}
+
+// isKnownEnum reports whether v is one of known.
+func isKnownEnum[T ~string](v T, known []T) bool {
+	for _, k := range known {
+		if v == k {
+			return true
+		}
+	}
+	return false
+}
+
+// WithStrictValidation makes the client reject params that contain enum
+// values unknown to this SDK version, or a chain/token pair billing.io does
+// not support, before any request is sent. By default such values are
+// passed through to the API.
+func WithStrictValidation() Option {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// enumChecker is implemented by params structs with enum fields.
+type enumChecker interface {
+	checkEnums(v *validator)
+}
+
+// knownEnum is satisfied by every enum type in this package.
+type knownEnum interface {
+	~string
+	IsKnown() bool
+}
+
+// checkEnum records an error if e is set but not a known value.
+func checkEnum[T knownEnum](v *validator, param string, e T) {
+	if e != "" && !e.IsKnown() {
+		v.addf(param, "unknown value %q", string(e))
+	}
+}
+
+// checkEnumPtr is like checkEnum for optional fields.
+func checkEnumPtr[T knownEnum](v *validator, param string, e *T) {
+	if e != nil {
+		checkEnum(v, param, *e)
+	}
+}
+
+// checkTokenPair records an error if token is not supported on chain.
+func checkTokenPair(v *validator, chain Chain, token Token) {
+	checkEnum(v, "chain", chain)
+	checkEnum(v, "token", token)
+	if !chain.IsKnown() || !token.IsKnown() {
+		return
+	}
+	if _, err := lookupToken(chain, token); err != nil {
+		v.addf("token", "%s is not supported on %s", token, chain)
+	}
+}
+
+func (p *CreateCheckoutParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkTokenPair(v, p.Chain, p.Token)
+}
+
+func (p *ListCheckoutsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *CreateWebhookParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	for i, e := range p.Events {
+		checkEnum(v, fmt.Sprintf("events[%d]", i), e)
+	}
+}
+
+func (p *UpdateWebhookParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	for i, e := range p.Events {
+		checkEnum(v, fmt.Sprintf("events[%d]", i), e)
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *SendTestWebhookEventParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnum(v, "type", p.Type)
+}
+
+func (p *ListWebhookDeliveriesParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "event_type", p.EventType)
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListEventsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "type", p.Type)
+}
+
+func (p *UpdateCustomerParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListCustomersParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *CreatePaymentMethodParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnum(v, "type", p.Type)
+	checkEnum(v, "chain", p.Chain)
+}
+
+func (p *UpdatePaymentMethodParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *CreatePaymentLinkParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	if p.Chain != nil && p.Token != nil {
+		checkTokenPair(v, *p.Chain, *p.Token)
+		return
+	}
+	checkEnumPtr(v, "chain", p.Chain)
+	checkEnumPtr(v, "token", p.Token)
+}
+
+func (p *CreateSubscriptionPlanParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnum(v, "billing_interval", p.BillingInterval)
+}
+
+func (p *UpdateSubscriptionPlanParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListSubscriptionPlansParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *UpdateSubscriptionParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListSubscriptionsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListSubscriptionRenewalsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *CreatePayoutParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkTokenPair(v, p.Chain, p.Token)
+}
+
+func (p *ListPayoutsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "status", p.Status)
+}
+
+func (p *ListRevenueEventsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "type", p.Type)
+}
+
+func (p *CreateAdjustmentParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnum(v, "type", p.Type)
+}
+
+func (p *ListAdjustmentsParams) checkEnums(v *validator) {
+	if p == nil {
+		return
+	}
+	checkEnumPtr(v, "type", p.Type)
+}
@@ -0,0 +1,33 @@
+package billingio
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumKeepsUnknownValues(t *testing.T) {
+	in := `{"webhook_id":"we_1","events":["checkout.completed","checkout.refunded"],"status":"paused"}`
+	var endpoint WebhookEndpoint
+	if err := json.Unmarshal([]byte(in), &endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Status != "paused" || endpoint.Status.IsKnown() {
+		t.Errorf("Status = %q, IsKnown = %v", endpoint.Status, endpoint.Status.IsKnown())
+	}
+	if e := endpoint.Events[1]; e != "checkout.refunded" || e.IsKnown() || e.Resource() != "checkout" {
+		t.Errorf("Events[1] = %q, IsKnown = %v", e, e.IsKnown())
+	}
+	if !endpoint.Events[0].IsKnown() {
+		t.Errorf("Events[0] = %q not known", endpoint.Events[0])
+	}
+
+	// A read-modify-write sends the server's values back unchanged.
+	params := UpdateWebhookParams{Events: endpoint.Events, Status: &endpoint.Status}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"events":["checkout.completed","checkout.refunded"],"status":"paused"}`; string(data) != want {
+		t.Errorf("params = %s, want %s", data, want)
+	}
+}
@@ -3,6 +3,7 @@ package billingio
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Error represents a structured API error returned by billing.io.
@@ -21,6 +22,17 @@ type Error struct {
 
 	// Param is the request parameter that caused the error, if applicable.
 	Param *string `json:"param"`
+
+	// RequestID is the value of the X-Request-Id response header. Quote it
+	// when contacting billing.io support.
+	RequestID string `json:"-"`
+
+	// RetryAfter is the delay requested by the server via the Retry-After
+	// header, or 0 if none was sent.
+	RetryAfter time.Duration `json:"-"`
+
+	// RateLimit holds the X-RateLimit-* headers of the response, if present.
+	RateLimit *RateLimit `json:"-"`
 }
 
 // Error implements the error interface.
@@ -29,6 +41,9 @@ func (e *Error) Error() string {
 	if e.Param != nil {
 		msg += fmt.Sprintf(", param=%s", *e.Param)
 	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(", request_id=%s", e.RequestID)
+	}
 	return msg
 }
 
@@ -11,7 +11,11 @@ type EventService struct {
 }
 
 // List returns a paginated list of events, newest first.
-func (s *EventService) List(ctx context.Context, params *ListEventsParams) (*EventList, error) {
+func (s *EventService) List(ctx context.Context, params *ListEventsParams, opts ...RequestOption) (*EventList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -24,7 +28,7 @@ func (s *EventService) List(ctx context.Context, params *ListEventsParams) (*Eve
 	path := addQueryParams("/events", qp)
 
 	var list EventList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Events.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,9 +36,9 @@ func (s *EventService) List(ctx context.Context, params *ListEventsParams) (*Eve
 }
 
 // Get retrieves a single event by ID.
-func (s *EventService) Get(ctx context.Context, eventID string) (*Event, error) {
+func (s *EventService) Get(ctx context.Context, eventID string, opts ...RequestOption) (*Event, error) {
 	var event Event
-	err := s.client.get(ctx, fmt.Sprintf("/events/%s", eventID), &event)
+	err := s.client.get(ctx, "Events.Get", fmt.Sprintf("/events/%s", eventID), &event, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,15 +47,15 @@ func (s *EventService) Get(ctx context.Context, eventID string) (*Event, error)
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of events. See Iter for usage details.
-func (s *EventService) ListAutoPaginate(ctx context.Context, params *ListEventsParams) *Iter[Event] {
+func (s *EventService) ListAutoPaginate(ctx context.Context, params *ListEventsParams, opts ...RequestOption) *Iter[Event] {
 	if params == nil {
 		params = &ListEventsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Event, bool, *string, error) {
+	return newIter(ctx, s.client, "Events", func(ctx context.Context, cursor *string) ([]Event, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -0,0 +1,100 @@
+package billingio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CheckpointStore persists the position of an EventService.Stream, so that
+// it resumes where it left off after a restart.
+//
+// Implement it over a database to commit the checkpoint in the same
+// transaction as the effects of handling an event.
+type CheckpointStore interface {
+	// Load returns the ID of the last event handled, or "" if none was
+	// saved.
+	Load(ctx context.Context) (string, error)
+
+	// Save records eventID as the last event handled.
+	Save(ctx context.Context, eventID string) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore held in memory, for streams
+// that need not survive a restart. The zero value is ready to use.
+type MemoryCheckpointStore struct {
+	mu      sync.Mutex
+	eventID string
+}
+
+// Load implements CheckpointStore.
+func (s *MemoryCheckpointStore) Load(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.eventID, nil
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventID = eventID
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore persisted to a local file. Each
+// Save replaces the file atomically.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore at path. The file is
+// created on the first Save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("billingio: failed to read checkpoint: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Save implements CheckpointStore.
+func (s *FileCheckpointStore) Save(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("billingio: failed to write checkpoint: %w", err)
+	}
+	_, err = tmp.WriteString(eventID + "\n")
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("billingio: failed to write checkpoint: %w", err)
+	}
+	return nil
+}
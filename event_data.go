@@ -0,0 +1,81 @@
+package billingio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Resource returns the kind of object events of type t carry in their Data,
+// e.g. "checkout" for checkout.completed or "renewal" for renewal.paid.
+func (t EventType) Resource() string {
+	resource, _, _ := strings.Cut(string(t), ".")
+	return resource
+}
+
+// AsCheckout decodes Data for checkout.* events.
+func (e *Event) AsCheckout() (*Checkout, error) {
+	return decodeEventData[Checkout](e.Type, e.Data, "checkout")
+}
+
+// AsSubscription decodes Data for subscription.* events.
+func (e *Event) AsSubscription() (*Subscription, error) {
+	return decodeEventData[Subscription](e.Type, e.Data, "subscription")
+}
+
+// AsRenewal decodes Data for renewal.* events.
+func (e *Event) AsRenewal() (*SubscriptionRenewal, error) {
+	return decodeEventData[SubscriptionRenewal](e.Type, e.Data, "renewal")
+}
+
+// AsPayout decodes Data for payout.* events.
+func (e *Event) AsPayout() (*Payout, error) {
+	return decodeEventData[Payout](e.Type, e.Data, "payout")
+}
+
+// AsSettlement decodes Data for settlement.* events.
+func (e *Event) AsSettlement() (*Settlement, error) {
+	return decodeEventData[Settlement](e.Type, e.Data, "settlement")
+}
+
+// AsCheckout decodes Data for checkout.* events.
+func (e *WebhookEvent) AsCheckout() (*Checkout, error) {
+	return decodeEventData[Checkout](e.Type, e.Data, "checkout")
+}
+
+// AsSubscription decodes Data for subscription.* events.
+func (e *WebhookEvent) AsSubscription() (*Subscription, error) {
+	return decodeEventData[Subscription](e.Type, e.Data, "subscription")
+}
+
+// AsRenewal decodes Data for renewal.* events.
+func (e *WebhookEvent) AsRenewal() (*SubscriptionRenewal, error) {
+	return decodeEventData[SubscriptionRenewal](e.Type, e.Data, "renewal")
+}
+
+// AsPayout decodes Data for payout.* events.
+func (e *WebhookEvent) AsPayout() (*Payout, error) {
+	return decodeEventData[Payout](e.Type, e.Data, "payout")
+}
+
+// AsSettlement decodes Data for settlement.* events.
+func (e *WebhookEvent) AsSettlement() (*Settlement, error) {
+	return decodeEventData[Settlement](e.Type, e.Data, "settlement")
+}
+
+// decodeEventData decodes data into a T after checking that events of type
+// typ carry resource. Events of a type unknown to this SDK version are
+// decoded as requested, since their resource cannot be checked.
+func decodeEventData[T any](typ EventType, data json.RawMessage, resource string) (*T, error) {
+	if typ.IsKnown() && typ.Resource() != resource {
+		return nil, fmt.Errorf("billingio: %s event does not carry a %s", typ, resource)
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil, fmt.Errorf("billingio: %s event has no data", typ)
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("billingio: failed to decode %s event data: %w", typ, err)
+	}
+	return &v, nil
+}
@@ -0,0 +1,202 @@
+package billingio
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StreamOptions configures EventService.Stream.
+type StreamOptions struct {
+	// Checkpoint, if set, records the ID of each event after fn handles it
+	// successfully. When it holds a saved ID, Stream resumes after that
+	// event instead of the one passed as from.
+	Checkpoint CheckpointStore
+
+	// Type and CheckoutID restrict the stream as in ListEventsParams.
+	Type       *EventType
+	CheckoutID *string
+
+	// PollInterval is the delay between polls for new events. Defaults to
+	// 3 seconds.
+	PollInterval time.Duration
+}
+
+// streamPosition is the last event delivered by Stream.
+type streamPosition struct {
+	id string
+	at time.Time
+}
+
+// Stream tails the account's events, passing each one to fn in chronological
+// order. It is a way to consume events without accepting inbound webhook
+// deliveries.
+//
+// Stream starts after the event with ID from. If from is empty and there is
+// no saved checkpoint, it starts with the events created after Stream is
+// called. Each event is passed to fn once; when opts.Checkpoint is set its ID
+// is saved after fn returns nil, so a restarted Stream resumes with the next
+// event. An event whose callback failed, or whose checkpoint was not saved
+// before the process stopped, is delivered again on resume.
+//
+// After a gap, Stream catches up a page at a time: it pages back to its
+// position keeping only the page cursors, then fetches and delivers the
+// pages oldest first. Events delivered before a failure stay checkpointed.
+//
+// Stream runs until ctx is done, fn returns an error, or a request or the
+// checkpoint store fails, and returns that error. opts may be nil.
+//
+//	store := billingio.NewFileCheckpointStore("events.checkpoint")
+//	err := client.Events.Stream(ctx, "", func(ctx context.Context, event *billingio.Event) error {
+//	    return process(ctx, event)
+//	}, &billingio.StreamOptions{Checkpoint: store})
+func (s *EventService) Stream(ctx context.Context, from string, fn func(ctx context.Context, event *Event) error, opts *StreamOptions, reqOpts ...RequestOption) error {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	limit := maxListLimit
+	params := ListEventsParams{Limit: &limit, Type: opts.Type, CheckoutID: opts.CheckoutID}
+
+	if opts.Checkpoint != nil {
+		saved, err := opts.Checkpoint.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("billingio: failed to load event checkpoint: %w", err)
+		}
+		if saved != "" {
+			from = saved
+		}
+	}
+
+	pos, err := s.streamStart(ctx, from, params, opts.Checkpoint, reqOpts)
+	if err != nil {
+		return err
+	}
+
+	deliver := func(e *Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(ctx, e); err != nil {
+			return err
+		}
+		pos = streamPosition{id: e.EventID, at: e.CreatedAt}
+		if opts.Checkpoint != nil {
+			if err := opts.Checkpoint.Save(ctx, pos.id); err != nil {
+				return fmt.Errorf("billingio: failed to save event checkpoint: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for {
+		if err := s.streamPoll(ctx, &pos, params, deliver, reqOpts); err != nil {
+			return err
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// streamStart resolves the position Stream starts from. With an empty from
+// it is the newest existing event, which is saved to store so that a restart
+// does not skip events created in between. If there are no events yet, it is
+// the zero position, which precedes every event.
+func (s *EventService) streamStart(ctx context.Context, from string, params ListEventsParams, store CheckpointStore, reqOpts []RequestOption) (streamPosition, error) {
+	if from != "" {
+		event, err := s.Get(ctx, from, reqOpts...)
+		if err != nil {
+			return streamPosition{}, err
+		}
+		return streamPosition{id: event.EventID, at: event.CreatedAt}, nil
+	}
+
+	one := 1
+	params.Limit = &one
+	list, err := s.List(ctx, &params, reqOpts...)
+	if err != nil {
+		return streamPosition{}, err
+	}
+	if len(list.Data) == 0 {
+		return streamPosition{}, nil
+	}
+	newest := list.Data[0]
+	if store != nil {
+		if err := store.Save(ctx, newest.EventID); err != nil {
+			return streamPosition{}, fmt.Errorf("billingio: failed to save event checkpoint: %w", err)
+		}
+	}
+	return streamPosition{id: newest.EventID, at: newest.CreatedAt}, nil
+}
+
+// streamPoll delivers the events newer than *pos, oldest first. The list is
+// newest first, so it pages back until it reaches *pos, keeping the cursors
+// of the pages in between, then delivers the page that reached *pos, fetches
+// and delivers the pages in between in reverse, and finally delivers the
+// first page, which is kept rather than fetched again so that events pushed
+// off it by new arrivals are not missed.
+func (s *EventService) streamPoll(ctx context.Context, pos *streamPosition, params ListEventsParams, deliver func(*Event) error, reqOpts []RequestOption) error {
+	first, err := s.List(ctx, &params, reqOpts...)
+	if err != nil {
+		return err
+	}
+	page := first.Data
+	reached := len(pos.newer(page)) < len(page)
+
+	var cursors []*string
+	next := first
+	for !reached && next.HasMore && next.NextCursor != nil {
+		params.Cursor = next.NextCursor
+		if next, err = s.List(ctx, &params, reqOpts...); err != nil {
+			return err
+		}
+		cursors = append(cursors, params.Cursor)
+		page = next.Data
+		reached = len(pos.newer(page)) < len(page)
+	}
+
+	// page holds the oldest page fetched; deliver it, then the pages in
+	// between, then the first page.
+	for i := len(cursors) - 1; i >= 0; i-- {
+		if i < len(cursors)-1 {
+			params.Cursor = cursors[i]
+			list, err := s.List(ctx, &params, reqOpts...)
+			if err != nil {
+				return err
+			}
+			page = list.Data
+		}
+		if err := deliverPage(page, pos, deliver); err != nil {
+			return err
+		}
+	}
+	return deliverPage(first.Data, pos, deliver)
+}
+
+// deliverPage passes the events of a newest-first page that are newer than
+// *pos to deliver, oldest first.
+func deliverPage(page []Event, pos *streamPosition, deliver func(*Event) error) error {
+	newer := pos.newer(page)
+	for i := len(newer) - 1; i >= 0; i-- {
+		if err := deliver(&newer[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newer returns the leading events of a newest-first page that come after
+// the position, stopping at the position's event. That event may be filtered
+// out of the list, so it also stops at the first older event.
+func (p *streamPosition) newer(page []Event) []Event {
+	for i, e := range page {
+		if e.EventID == p.id || e.CreatedAt.Before(p.at) {
+			return page[:i]
+		}
+	}
+	return page
+}
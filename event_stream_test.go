@@ -0,0 +1,208 @@
+package billingio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventServer serves /events from an in-memory log, newest first, with
+// cursors naming the last event of the previous page.
+type eventServer struct {
+	mu       sync.Mutex
+	events   []Event // oldest first
+	pageSize int
+}
+
+func (s *eventServer) add(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		k := len(s.events)
+		s.events = append(s.events, Event{
+			EventID: fmt.Sprintf("evt_%d", k),
+			Type:    EventTypeCheckoutCreated,
+			// Pairs of events share a timestamp.
+			CreatedAt: time.Unix(int64(1000+k/2), 0).UTC(),
+		})
+	}
+}
+
+func (s *eventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := strings.CutPrefix(r.URL.Path, "/events/"); ok {
+		for _, e := range s.events {
+			if e.EventID == id {
+				json.NewEncoder(w).Encode(e)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"type":"invalid_request","code":"not_found","message":"no such event"}}`)
+		return
+	}
+
+	var limit int
+	fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+	limit = min(limit, s.pageSize)
+	i := len(s.events) - 1
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		for i >= 0 && s.events[i].EventID != cursor {
+			i--
+		}
+		i--
+	}
+	list := EventList{Data: []Event{}}
+	for ; i >= 0 && len(list.Data) < limit; i-- {
+		list.Data = append(list.Data, s.events[i])
+	}
+	if n := len(list.Data); n > 0 && i >= 0 {
+		list.HasMore = true
+		list.NextCursor = &list.Data[n-1].EventID
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// stream runs Stream until want events were delivered, or fn fails at
+// failAt, and returns the delivered event IDs and Stream's error.
+func stream(t *testing.T, client *Client, from string, store CheckpointStore, want int, failAt string, during func(n int)) ([]string, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	err := client.Events.Stream(ctx, from, func(ctx context.Context, event *Event) error {
+		if event.EventID == failAt {
+			return errors.New("callback failed")
+		}
+		got = append(got, event.EventID)
+		if during != nil {
+			during(len(got))
+		}
+		if len(got) == want {
+			cancel()
+		}
+		return nil
+	}, &StreamOptions{Checkpoint: store, PollInterval: 10 * time.Millisecond})
+	return got, err
+}
+
+func ids(from, to int) []string {
+	var out []string
+	for i := from; i <= to; i++ {
+		out = append(out, fmt.Sprintf("evt_%d", i))
+	}
+	return out
+}
+
+func TestStreamEmptyAtStart(t *testing.T) {
+	srv := &eventServer{pageSize: 3}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := New("sk_test", WithBaseURL(ts.URL))
+
+	// Events created after Stream started, while there were none before.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		srv.add(2)
+		time.Sleep(50 * time.Millisecond)
+		srv.add(1)
+	}()
+
+	store := &MemoryCheckpointStore{}
+	got, err := stream(t, client, "", store, 3, "", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Stream error = %v", err)
+	}
+	if want := ids(0, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+	if cp, _ := store.Load(context.Background()); cp != "evt_2" {
+		t.Errorf("checkpoint = %q, want evt_2", cp)
+	}
+}
+
+func TestStreamStartsAfterNewest(t *testing.T) {
+	srv := &eventServer{pageSize: 3}
+	srv.add(4)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := New("sk_test", WithBaseURL(ts.URL))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		srv.add(2)
+	}()
+
+	got, err := stream(t, client, "", nil, 2, "", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Stream error = %v", err)
+	}
+	if want := ids(4, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestStreamCatchUpAndResume(t *testing.T) {
+	srv := &eventServer{pageSize: 3}
+	srv.add(20)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := New("sk_test", WithBaseURL(ts.URL))
+
+	// Catch up over several pages from evt_2, adding events meanwhile,
+	// and stop at a failing callback.
+	store := &MemoryCheckpointStore{}
+	got, err := stream(t, client, "evt_2", store, -1, "evt_17", func(n int) {
+		if n == 5 {
+			srv.add(5)
+		}
+	})
+	if err == nil || err.Error() != "callback failed" {
+		t.Fatalf("Stream error = %v, want callback failed", err)
+	}
+	if want := ids(3, 16); !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+	if cp, _ := store.Load(context.Background()); cp != "evt_16" {
+		t.Errorf("checkpoint = %q, want evt_16", cp)
+	}
+
+	// Resume from the checkpoint, which takes precedence over from.
+	got, err = stream(t, client, "evt_0", store, 8, "", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Stream error = %v", err)
+	}
+	if want := ids(17, 24); !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed with %v, want %v", got, want)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	path := t.TempDir() + "/checkpoint"
+	store := NewFileCheckpointStore(path)
+	ctx := context.Background()
+
+	if id, err := store.Load(ctx); err != nil || id != "" {
+		t.Fatalf("Load = %q, %v, want empty", id, err)
+	}
+	if err := store.Save(ctx, "evt_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "evt_2"); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := NewFileCheckpointStore(path).Load(ctx); err != nil || id != "evt_2" {
+		t.Errorf("Load = %q, %v, want evt_2", id, err)
+	}
+}
@@ -8,9 +8,9 @@ type HealthService struct {
 }
 
 // Get performs a health check. This endpoint does not require authentication.
-func (s *HealthService) Get(ctx context.Context) (*HealthResponse, error) {
+func (s *HealthService) Get(ctx context.Context, opts ...RequestOption) (*HealthResponse, error) {
 	var resp HealthResponse
-	err := s.client.get(ctx, "/health", &resp)
+	err := s.client.get(ctx, "Health.Get", "/health", &resp, opts...)
 	if err != nil {
 		return nil, err
 	}
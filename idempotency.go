@@ -0,0 +1,21 @@
+package billingio
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// idempotencyKeyHeader is the HTTP header carrying the idempotency key.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random UUIDv4 string.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand only fails if the OS entropy source is broken.
+		panic(fmt.Sprintf("billingio: failed to generate idempotency key: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,187 @@
+package billingio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redacted replaces sensitive values in log output.
+const redacted = "[REDACTED]"
+
+// LogRedaction selects which values are masked in log output. API keys and
+// webhook signing secrets are always masked.
+type LogRedaction int
+
+const (
+	// RedactCredentials masks the Authorization header and webhook signing
+	// secrets. It is the default.
+	RedactCredentials LogRedaction = iota
+
+	// RedactWalletAddresses additionally masks wallet and deposit addresses.
+	RedactWalletAddresses
+)
+
+// secretFields are JSON fields that are always redacted from logged bodies.
+var secretFields = map[string]bool{
+	"secret": true,
+}
+
+// addressFields are JSON fields redacted under RedactWalletAddresses.
+var addressFields = map[string]bool{
+	"wallet_address":  true,
+	"deposit_address": true,
+}
+
+// WithLogger enables structured logging of API traffic to l.
+//
+// Each HTTP attempt is logged with its method, path, status, duration,
+// request ID and attempt number. Successful requests are logged at the level
+// set by WithLogLevel (Info by default), client errors at Warn and server or
+// network errors at Error. API error messages are not logged, only their
+// type, code and status; redacted request and response bodies, which carry
+// the details, are logged at Debug.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithLogLevel sets the level at which successful requests are logged.
+func WithLogLevel(level slog.Level) Option {
+	return func(c *Client) {
+		c.logLevel = level
+	}
+}
+
+// WithLogRedaction sets which values are masked in log output.
+func WithLogRedaction(r LogRedaction) Option {
+	return func(c *Client) {
+		c.logRedaction = r
+	}
+}
+
+// logAttempt logs the outcome of a single HTTP attempt.
+func (c *Client) logAttempt(ctx context.Context, req *Request, meta *Response, elapsed time.Duration, respBody []byte, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	level := c.logLevel
+	msg := "billingio: request completed"
+	if err != nil {
+		msg = "billingio: request failed"
+		level = slog.LevelError
+		if meta != nil && meta.StatusCode < 500 {
+			level = slog.LevelWarn
+		}
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", req.Service+"."+req.Operation),
+		slog.String("method", req.Method),
+		slog.String("path", req.Path),
+		slog.Int("attempt", req.Attempt),
+		slog.Duration("duration", elapsed),
+	}
+	if meta != nil {
+		attrs = append(attrs,
+			slog.Int("status", meta.StatusCode),
+			slog.String("request_id", meta.RequestID),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", logError(err)))
+	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", c.redactHeaders(req.Header)))
+		if req.body != nil {
+			attrs = append(attrs, slog.String("request_body", c.redactBody(req.body)))
+		}
+		if len(respBody) > 0 {
+			attrs = append(attrs, slog.String("response_body", c.redactBody(respBody)))
+		}
+	}
+
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logRetry logs that a failed attempt is about to be retried after delay.
+func (c *Client) logRetry(ctx context.Context, req *Request, delay time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "billingio: retrying request",
+		slog.String("operation", req.Service+"."+req.Operation),
+		slog.String("method", req.Method),
+		slog.String("path", req.Path),
+		slog.Int("attempt", req.Attempt),
+		slog.Duration("delay", delay),
+		slog.String("error", logError(err)),
+	)
+}
+
+// logError describes err for log output. An API error is reduced to its
+// type, code and status, since its message may echo request data or, for a
+// response without an error envelope, embed the raw body; the redacted body
+// is logged at Debug instead.
+func logError(err error) string {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	msg := fmt.Sprintf("billingio: %s (code=%s, status=%d)", apiErr.Type, apiErr.Code, apiErr.StatusCode)
+	if apiErr.Param != nil {
+		msg += fmt.Sprintf(", param=%s", *apiErr.Param)
+	}
+	return msg
+}
+
+// redactHeaders returns a copy of h with credentials masked.
+func (c *Client) redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+redacted)
+	}
+	return out
+}
+
+// redactBody masks sensitive fields in a JSON body. Bodies that are not
+// valid JSON are replaced entirely, since their contents are unknown.
+func (c *Client) redactBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return redacted
+	}
+	v = c.redactValue(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+// redactValue walks a decoded JSON value and masks sensitive fields.
+func (c *Client) redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if secretFields[k] || (c.logRedaction == RedactWalletAddresses && addressFields[k]) {
+				if val != nil {
+					t[k] = redacted
+				}
+				continue
+			}
+			t[k] = c.redactValue(val)
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = c.redactValue(val)
+		}
+	}
+	return v
+}
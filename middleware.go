@@ -0,0 +1,87 @@
+package billingio
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Request describes a single HTTP attempt of an API call. It is passed
+// through the middleware chain before being sent.
+type Request struct {
+	// Service is the client service handling the call (e.g. "Checkouts").
+	Service string
+
+	// Operation is the service method being called (e.g. "Create").
+	Operation string
+
+	// Method is the HTTP method.
+	Method string
+
+	// Path is the request path relative to the base URL, including any
+	// query string.
+	Path string
+
+	// IdempotencyKey is the Idempotency-Key sent with the request, if any.
+	IdempotencyKey string
+
+	// Attempt is the 1-based attempt number; values above 1 are retries.
+	Attempt int
+
+	// Header holds the request headers, including Authorization.
+	// Middleware may modify it before calling the next handler.
+	Header http.Header
+
+	// Params is the request body before encoding, or nil. It is encoded
+	// once per call, so changes made by middleware are not sent.
+	Params any
+
+	// Result is the value the response body is decoded into. It holds the
+	// decoded resource once the next handler returns without error.
+	Result any
+
+	body []byte
+}
+
+// Handler sends a Request and returns the response metadata. API failures
+// are reported as *Error.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to observe or alter requests and responses.
+// It is invoked once per HTTP attempt, so retries pass through it again.
+//
+//	logging := func(next billingio.Handler) billingio.Handler {
+//	    return func(ctx context.Context, req *billingio.Request) (*billingio.Response, error) {
+//	        resp, err := next(ctx, req)
+//	        log.Printf("%s.%s attempt=%d err=%v", req.Service, req.Operation, req.Attempt, err)
+//	        return resp, err
+//	    }
+//	}
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the client's chain. Middleware
+// registered first is outermost, i.e. it sees the request first and the
+// response last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// buildHandler wraps base with the client's middleware chain.
+func (c *Client) buildHandler(base Handler) Handler {
+	h := base
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// splitOperation splits an operation name such as "Checkouts.Create" into
+// its service and method parts.
+func splitOperation(op string) (service, name string) {
+	if i := strings.IndexByte(op, '.'); i >= 0 {
+		return op[:i], op[i+1:]
+	}
+	return "", op
+}
@@ -0,0 +1,234 @@
+package billingio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact US dollar amount, stored as an integer number of cents.
+// The zero value is $0.00.
+//
+// Money marshals to and from the plain JSON numbers used by the API (e.g.
+// 49.99) without going through float64, so amounts can be summed and
+// compared exactly. Create values with ParseMoney, MustParseMoney or Cents;
+// MoneyFromFloat eases migration from code that used float64 amounts.
+type Money struct {
+	cents int64
+}
+
+// Cents returns a Money value of c cents.
+func Cents(c int64) Money {
+	return Money{cents: c}
+}
+
+// ParseMoney parses a decimal dollar amount such as "49.99", "-10" or
+// "0.5". Amounts with sub-cent precision (e.g. "1.005") are rejected rather
+// than rounded, as is any other number syntax, such as "1e3", "0x10" or
+// "1_000".
+func ParseMoney(s string) (Money, error) {
+	return parseMoney(s, false)
+}
+
+// parseMoney parses s, which must match -?\d+(\.\d+)? optionally followed
+// by an exponent if exponent is set.
+func parseMoney(s string, exponent bool) (Money, error) {
+	if !isDecimal(s, exponent) {
+		return Money{}, fmt.Errorf("billingio: invalid money amount %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Money{}, fmt.Errorf("billingio: invalid money amount %q", s)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("billingio: money amount %q has more than two decimal places", s)
+	}
+	n := r.Num()
+	if !n.IsInt64() {
+		return Money{}, fmt.Errorf("billingio: money amount %q out of range", s)
+	}
+	return Money{cents: n.Int64()}, nil
+}
+
+// isDecimal reports whether s is a plain decimal number, with an optional
+// exponent (as in an unquoted JSON number) if exponent is set. It keeps
+// big.Rat's wider syntax, such as "0x10" or "1/3", out of ParseMoney.
+func isDecimal(s string, exponent bool) bool {
+	s = strings.TrimPrefix(s, "-")
+	s, ok := cutDigits(s)
+	if !ok {
+		return false
+	}
+	if rest, found := strings.CutPrefix(s, "."); found {
+		if s, ok = cutDigits(rest); !ok {
+			return false
+		}
+	}
+	if exponent && s != "" && (s[0] == 'e' || s[0] == 'E') {
+		s = s[1:]
+		if s != "" && (s[0] == '+' || s[0] == '-') {
+			s = s[1:]
+		}
+		// A few exponent digits cover any amount in range; more would
+		// only make big.Rat build a huge number.
+		rest, ok := cutDigits(s)
+		if !ok || len(s)-len(rest) > 3 {
+			return false
+		}
+		s = rest
+	}
+	return s == ""
+}
+
+// cutDigits removes the leading ASCII digits of s and reports whether there
+// was at least one.
+func cutDigits(s string) (string, bool) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[i:], i > 0
+}
+
+// MustParseMoney is like ParseMoney but panics if s is invalid. It is
+// intended for constants in code and tests.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MoneyFromFloat converts a float64 dollar amount to Money, rounding to the
+// nearest cent. It exists to migrate code written against the former
+// float64 amount fields; prefer ParseMoney or Cents in new code.
+func MoneyFromFloat(f float64) Money {
+	return Money{cents: int64(math.Round(f * 100))}
+}
+
+// Cents returns the amount as an integer number of cents.
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Float64 returns the amount in dollars as a float64. The result may not be
+// exact; use it only for display or interop with float-based code.
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// String formats the amount with exactly two decimal places, e.g. "49.99"
+// or "-0.50".
+func (m Money) String() string {
+	c := m.cents
+	sign := ""
+	if c < 0 {
+		sign = "-"
+	}
+	// Work with the magnitude as uint64 so math.MinInt64 does not overflow.
+	u := uint64(c)
+	if c < 0 {
+		u = uint64(-(c + 1)) + 1
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, u/100, u%100)
+}
+
+// Add returns m + o.
+func (m Money) Add(o Money) Money {
+	return Money{cents: m.cents + o.cents}
+}
+
+// Sub returns m - o.
+func (m Money) Sub(o Money) Money {
+	return Money{cents: m.cents - o.cents}
+}
+
+// Mul returns m multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return Money{cents: m.cents * n}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents}
+}
+
+// Abs returns the absolute value of m.
+func (m Money) Abs() Money {
+	if m.cents < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Cmp compares m and o and returns -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.cents < o.cents:
+		return -1
+	case m.cents > o.cents:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether m and o are the same amount.
+func (m Money) Equal(o Money) bool {
+	return m.cents == o.cents
+}
+
+// IsZero reports whether m is $0.00.
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+// SumMoney returns the sum of amounts.
+func SumMoney(amounts ...Money) Money {
+	var total Money
+	for _, a := range amounts {
+		total = total.Add(a)
+	}
+	return total
+}
+
+// MarshalJSON encodes m as a JSON number with two decimal places.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or a string holding a decimal amount
+// exactly. null leaves m unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	quoted := len(data) > 0 && data[0] == '"'
+	if quoted {
+		var err error
+		s, err = strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("billingio: invalid money amount %s", data)
+		}
+	} else if !json.Valid(data) {
+		return fmt.Errorf("billingio: invalid money amount %s", data)
+	}
+	v, err := parseMoney(s, !quoted)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
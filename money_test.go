@@ -0,0 +1,147 @@
+package billingio
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in    string
+		cents int64
+		ok    bool
+	}{
+		{"49.99", 4999, true},
+		{"0", 0, true},
+		{"0.5", 50, true},
+		{"10", 1000, true},
+		{"-10", -1000, true},
+		{"-0.01", -1, true},
+		{"007.10", 710, true},
+		{"92233720368547758.07", math.MaxInt64, true},
+		{"-92233720368547758.08", math.MinInt64, true},
+
+		{"92233720368547758.08", 0, false}, // out of range
+		{"1.005", 0, false},                // sub-cent
+		{"", 0, false},
+		{"-", 0, false},
+		{".5", 0, false},
+		{"5.", 0, false},
+		{"+5", 0, false},
+		{" 5", 0, false},
+		{"1e3", 0, false},
+		{"0x10", 0, false},
+		{"0o17", 0, false},
+		{"0b11", 0, false},
+		{"1_000", 0, false},
+		{"0x1p-2", 0, false},
+		{"1/2", 0, false},
+		{"NaN", 0, false},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in)
+		if tt.ok != (err == nil) {
+			t.Errorf("ParseMoney(%q) error = %v, want ok = %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && m.Cents() != tt.cents {
+			t.Errorf("ParseMoney(%q) = %d cents, want %d", tt.in, m.Cents(), tt.cents)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		cents int64
+		want  string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{4999, "49.99"},
+		{-50, "-0.50"},
+		{-100000, "-1000.00"},
+		{math.MaxInt64, "92233720368547758.07"},
+		{math.MinInt64, "-92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		if got := Cents(tt.cents).String(); got != tt.want {
+			t.Errorf("Cents(%d).String() = %q, want %q", tt.cents, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	type body struct {
+		A Money `json:"a"`
+	}
+
+	for _, cents := range []int64{0, 1, -1, 4999, -4999, math.MaxInt64, math.MinInt64} {
+		in := body{A: Cents(cents)}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", cents, err)
+		}
+		var out body
+		if err := json.Unmarshal(data, &out); err != nil || out != in {
+			t.Errorf("round trip of %d cents via %s = %d, %v", cents, data, out.A.Cents(), err)
+		}
+	}
+
+	decode := []struct {
+		in    string
+		cents int64
+		ok    bool
+	}{
+		{`{"a":49.99}`, 4999, true},
+		{`{"a":"49.99"}`, 4999, true},
+		{`{"a":-0.5}`, -50, true},
+		{`{"a":1.5e2}`, 15000, true},
+		{`{"a":1E-2}`, 1, true},
+		{`{"a":null}`, 0, true},
+
+		{`{"a":"1e2"}`, 0, false}, // exponents only in JSON numbers
+		{`{"a":"0x1p4"}`, 0, false},
+		{`{"a":"0x10"}`, 0, false},
+		{`{"a":"1_000"}`, 0, false},
+		{`{"a":1e1000000}`, 0, false},
+		{`{"a":0.001}`, 0, false},
+		{`{"a":true}`, 0, false},
+	}
+	for _, tt := range decode {
+		var out body
+		err := json.Unmarshal([]byte(tt.in), &out)
+		if tt.ok != (err == nil) {
+			t.Errorf("Unmarshal(%s) error = %v, want ok = %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && out.A.Cents() != tt.cents {
+			t.Errorf("Unmarshal(%s) = %d cents, want %d", tt.in, out.A.Cents(), tt.cents)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := MustParseMoney("10.25"), MustParseMoney("-3.50")
+	if got := a.Add(b); got != Cents(675) {
+		t.Errorf("Add = %s", got)
+	}
+	if got := a.Sub(b); got != Cents(1375) {
+		t.Errorf("Sub = %s", got)
+	}
+	if got := b.Mul(3); got != Cents(-1050) {
+		t.Errorf("Mul = %s", got)
+	}
+	if got := b.Abs(); got != Cents(350) || !b.IsNegative() || b.Neg() != Cents(350) {
+		t.Errorf("Abs = %s", got)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Error("Cmp ordering wrong")
+	}
+	if got := SumMoney(a, b, Cents(25)); got != Cents(700) {
+		t.Errorf("SumMoney = %s", got)
+	}
+	if got := MoneyFromFloat(0.1 + 0.2); got != Cents(30) {
+		t.Errorf("MoneyFromFloat = %s", got)
+	}
+}
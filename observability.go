@@ -0,0 +1,127 @@
+package billingio
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tracer starts spans around API calls and page fetches. It is a minimal
+// interface so the SDK stays free of tracing dependencies; see the
+// billingotel module for an OpenTelemetry adapter.
+type Tracer interface {
+	// Start begins a span named name as a child of any span in ctx and
+	// returns a context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a unit of traced work started by a Tracer.
+type Span interface {
+	// SetAttribute records a key/value attribute on the span. value is a
+	// string, int or bool.
+	SetAttribute(key string, value any)
+
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// MetricsRecorder receives measurements of API calls and page fetches. See
+// the billingprom module for a Prometheus adapter.
+type MetricsRecorder interface {
+	// RecordRequest is called once per API call, after any retries.
+	RecordRequest(ctx context.Context, m RequestMetrics)
+
+	// RecordPageFetch is called each time an Iter fetches a page.
+	RecordPageFetch(ctx context.Context, m PageMetrics)
+}
+
+// RequestMetrics describes a completed API call.
+type RequestMetrics struct {
+	// Service and Operation identify the SDK method (e.g. "Checkouts", "Create").
+	Service   string
+	Operation string
+
+	// Method is the HTTP method.
+	Method string
+
+	// StatusCode is the HTTP status of the final attempt, or 0 if no
+	// response was received.
+	StatusCode int
+
+	// Duration is the total time spent, including retries and backoff.
+	Duration time.Duration
+
+	// Retries is the number of attempts beyond the first.
+	Retries int
+
+	// ErrorType classifies the failure, or is empty on success. API errors
+	// report Error.Type; other values are "network", "canceled" and "client".
+	ErrorType string
+}
+
+// PageMetrics describes a page fetched by an Iter.
+type PageMetrics struct {
+	// Service identifies the paginated resource (e.g. "Checkouts").
+	Service string
+
+	// Items is the number of items on the page.
+	Items int
+
+	// Duration is the time taken to fetch the page.
+	Duration time.Duration
+
+	// ErrorType classifies the failure, or is empty on success.
+	ErrorType string
+}
+
+// WithTracer enables tracing of API calls and page fetches.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) {
+		c.tracer = t
+	}
+}
+
+// WithMetrics enables recording of API call and page fetch metrics.
+func WithMetrics(m MetricsRecorder) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// startSpan starts a span named "billingio.<op>", or returns a no-op span if
+// no tracer is configured.
+func (c *Client) startSpan(ctx context.Context, op string) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return c.tracer.Start(ctx, "billingio."+op)
+}
+
+// noopSpan is used when tracing is disabled.
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) RecordError(error)        {}
+func (noopSpan) End()                     {}
+
+// errorType classifies err for metrics and tracing.
+func errorType(err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Type
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "canceled"
+	}
+	var netErr *requestError
+	if errors.As(err, &netErr) {
+		return "network"
+	}
+	return "client"
+}
@@ -1,9 +1,14 @@
 package billingio
 
+import (
+	"context"
+	"time"
+)
+
 // pageFunc fetches a single page of results. It receives the cursor for the
 // page to fetch (nil for the first page) and returns the items, whether more
 // pages exist, the next cursor, and any error.
-type pageFunc[T any] func(cursor *string) (items []T, hasMore bool, nextCursor *string, err error)
+type pageFunc[T any] func(ctx context.Context, cursor *string) (items []T, hasMore bool, nextCursor *string, err error)
 
 // Iter is a generic auto-pagination iterator. It lazily fetches pages from the
 // API as you advance through results.
@@ -19,6 +24,9 @@ type pageFunc[T any] func(cursor *string) (items []T, hasMore bool, nextCursor *
 //	    log.Fatal(err)
 //	}
 type Iter[T any] struct {
+	ctx     context.Context
+	client  *Client
+	service string
 	fetch   pageFunc[T]
 	items   []T
 	index   int
@@ -28,14 +36,44 @@ type Iter[T any] struct {
 	started bool
 }
 
-// newIter creates a new Iter using the given page-fetching function.
-func newIter[T any](fetch pageFunc[T]) *Iter[T] {
+// newIter creates a new Iter using the given page-fetching function. Page
+// fetches are traced and measured by c under the given service name.
+func newIter[T any](ctx context.Context, c *Client, service string, fetch pageFunc[T]) *Iter[T] {
 	return &Iter[T]{
+		ctx:     ctx,
+		client:  c,
+		service: service,
 		fetch:   fetch,
 		hasMore: true, // assume there is at least one page
 	}
 }
 
+// fetchPage loads the page at cursor, reporting it to the client's tracer
+// and metrics recorder.
+func (it *Iter[T]) fetchPage(cursor *string) ([]T, bool, *string, error) {
+	ctx, span := it.client.startSpan(it.ctx, it.service+".Page")
+	span.SetAttribute("billingio.service", it.service)
+
+	start := time.Now()
+	items, hasMore, nextCursor, err := it.fetch(ctx, cursor)
+	elapsed := time.Since(start)
+
+	span.SetAttribute("billingio.page_items", len(items))
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+	if it.client.metrics != nil {
+		it.client.metrics.RecordPageFetch(ctx, PageMetrics{
+			Service:   it.service,
+			Items:     len(items),
+			Duration:  elapsed,
+			ErrorType: errorType(err),
+		})
+	}
+	return items, hasMore, nextCursor, err
+}
+
 // Next advances the iterator to the next item. It returns false when there are
 // no more items or an error occurred. Call Current to get the item and Err to
 // check for errors.
@@ -48,7 +86,7 @@ func (it *Iter[T]) Next() bool {
 	// page and there are more pages to fetch, load the next page.
 	if !it.started || (it.index >= len(it.items) && it.hasMore) {
 		it.started = true
-		items, hasMore, nextCursor, err := it.fetch(it.cursor)
+		items, hasMore, nextCursor, err := it.fetchPage(it.cursor)
 		if err != nil {
 			it.err = err
 			return false
@@ -8,9 +8,20 @@ type PaymentLinkService struct {
 }
 
 // Create creates a new payment link.
-func (s *PaymentLinkService) Create(ctx context.Context, params *CreatePaymentLinkParams) (*PaymentLink, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *PaymentLinkService) Create(ctx context.Context, params *CreatePaymentLinkParams, opts ...RequestOption) (*PaymentLink, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var link PaymentLink
-	err := s.client.post(ctx, "/payment-links", params, &link, nil)
+	err := s.client.post(ctx, "PaymentLinks.Create", "/payment-links", params, &link, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +29,11 @@ func (s *PaymentLinkService) Create(ctx context.Context, params *CreatePaymentLi
 }
 
 // List returns a paginated list of payment links.
-func (s *PaymentLinkService) List(ctx context.Context, params *ListPaymentLinksParams) (*PaymentLinkList, error) {
+func (s *PaymentLinkService) List(ctx context.Context, params *ListPaymentLinksParams, opts ...RequestOption) (*PaymentLinkList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -27,7 +42,7 @@ func (s *PaymentLinkService) List(ctx context.Context, params *ListPaymentLinksP
 	path := addQueryParams("/payment-links", qp)
 
 	var list PaymentLinkList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "PaymentLinks.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -36,15 +51,15 @@ func (s *PaymentLinkService) List(ctx context.Context, params *ListPaymentLinksP
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of payment links. See Iter for usage details.
-func (s *PaymentLinkService) ListAutoPaginate(ctx context.Context, params *ListPaymentLinksParams) *Iter[PaymentLink] {
+func (s *PaymentLinkService) ListAutoPaginate(ctx context.Context, params *ListPaymentLinksParams, opts ...RequestOption) *Iter[PaymentLink] {
 	if params == nil {
 		params = &ListPaymentLinksParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]PaymentLink, bool, *string, error) {
+	return newIter(ctx, s.client, "PaymentLinks", func(ctx context.Context, cursor *string) ([]PaymentLink, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
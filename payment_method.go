@@ -11,9 +11,28 @@ type PaymentMethodService struct {
 }
 
 // Create creates a new payment method.
-func (s *PaymentMethodService) Create(ctx context.Context, params *CreatePaymentMethodParams) (*PaymentMethod, error) {
+//
+// The wallet address is checked and normalized for params.Chain before the
+// request is sent (see the address package). If params.IdempotencyKey is set
+// it is sent as the Idempotency-Key header; otherwise a key is generated for
+// the call.
+func (s *PaymentMethodService) Create(ctx context.Context, params *CreatePaymentMethodParams, opts ...RequestOption) (*PaymentMethod, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	// Send the canonical form of the address (e.g. EIP-55 checksummed)
+	// without modifying the caller's params.
+	body := params
+	if params != nil {
+		p := *params
+		p.WalletAddress = normalizeAddress(p.Chain, p.WalletAddress)
+		body = &p
+		opts = withParamsIdempotencyKey(p.IdempotencyKey, opts)
+	}
+
 	var pm PaymentMethod
-	err := s.client.post(ctx, "/payment-methods", params, &pm, nil)
+	err := s.client.post(ctx, "PaymentMethods.Create", "/payment-methods", body, &pm, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +40,11 @@ func (s *PaymentMethodService) Create(ctx context.Context, params *CreatePayment
 }
 
 // List returns a paginated list of payment methods.
-func (s *PaymentMethodService) List(ctx context.Context, params *ListPaymentMethodsParams) (*PaymentMethodList, error) {
+func (s *PaymentMethodService) List(ctx context.Context, params *ListPaymentMethodsParams, opts ...RequestOption) (*PaymentMethodList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -31,7 +54,7 @@ func (s *PaymentMethodService) List(ctx context.Context, params *ListPaymentMeth
 	path := addQueryParams("/payment-methods", qp)
 
 	var list PaymentMethodList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "PaymentMethods.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,9 +62,13 @@ func (s *PaymentMethodService) List(ctx context.Context, params *ListPaymentMeth
 }
 
 // Update updates an existing payment method.
-func (s *PaymentMethodService) Update(ctx context.Context, paymentMethodID string, params *UpdatePaymentMethodParams) (*PaymentMethod, error) {
+func (s *PaymentMethodService) Update(ctx context.Context, paymentMethodID string, params *UpdatePaymentMethodParams, opts ...RequestOption) (*PaymentMethod, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var pm PaymentMethod
-	err := s.client.patch(ctx, fmt.Sprintf("/payment-methods/%s", paymentMethodID), params, &pm)
+	err := s.client.patch(ctx, "PaymentMethods.Update", fmt.Sprintf("/payment-methods/%s", paymentMethodID), params, &pm, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -49,14 +76,14 @@ func (s *PaymentMethodService) Update(ctx context.Context, paymentMethodID strin
 }
 
 // Delete removes a payment method.
-func (s *PaymentMethodService) Delete(ctx context.Context, paymentMethodID string) error {
-	return s.client.del(ctx, fmt.Sprintf("/payment-methods/%s", paymentMethodID))
+func (s *PaymentMethodService) Delete(ctx context.Context, paymentMethodID string, opts ...RequestOption) error {
+	return s.client.del(ctx, "PaymentMethods.Delete", fmt.Sprintf("/payment-methods/%s", paymentMethodID), opts...)
 }
 
 // SetDefault marks a payment method as the default for its customer.
-func (s *PaymentMethodService) SetDefault(ctx context.Context, paymentMethodID string) (*PaymentMethod, error) {
+func (s *PaymentMethodService) SetDefault(ctx context.Context, paymentMethodID string, opts ...RequestOption) (*PaymentMethod, error) {
 	var pm PaymentMethod
-	err := s.client.post(ctx, fmt.Sprintf("/payment-methods/%s/default", paymentMethodID), nil, &pm, nil)
+	err := s.client.post(ctx, "PaymentMethods.SetDefault", fmt.Sprintf("/payment-methods/%s/default", paymentMethodID), nil, &pm, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,15 +92,15 @@ func (s *PaymentMethodService) SetDefault(ctx context.Context, paymentMethodID s
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of payment methods. See Iter for usage details.
-func (s *PaymentMethodService) ListAutoPaginate(ctx context.Context, params *ListPaymentMethodsParams) *Iter[PaymentMethod] {
+func (s *PaymentMethodService) ListAutoPaginate(ctx context.Context, params *ListPaymentMethodsParams, opts ...RequestOption) *Iter[PaymentMethod] {
 	if params == nil {
 		params = &ListPaymentMethodsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]PaymentMethod, bool, *string, error) {
+	return newIter(ctx, s.client, "PaymentMethods", func(ctx context.Context, cursor *string) ([]PaymentMethod, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -0,0 +1,67 @@
+package billingio
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/billing-io/billing-go/qrcode"
+)
+
+// evmChainIDs maps EVM chains to their EIP-155 chain IDs.
+var evmChainIDs = map[Chain]int{
+	ChainArbitrum: 42161,
+}
+
+// PaymentURI returns a wallet deep link that pays the checkout's amount of
+// its token to DepositAddress.
+//
+// Arbitrum checkouts use an EIP-681 token transfer URI:
+//
+//	ethereum:<token contract>@42161/transfer?address=<deposit address>&uint256=<atomic amount>
+//
+// Tron checkouts use the equivalent URI understood by Tron wallets, with the
+// amount in whole tokens:
+//
+//	tron:<deposit address>?token=<token contract>&amount=<amount>
+func (c *Checkout) PaymentURI() (string, error) {
+	if c.DepositAddress == "" {
+		return "", fmt.Errorf("billingio: checkout %s has no deposit address", c.CheckoutID)
+	}
+	info, err := c.TokenInfo()
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.AmountAtomicInt(); err != nil {
+		return "", err
+	}
+
+	if chainID, ok := evmChainIDs[c.Chain]; ok {
+		q := url.Values{}
+		q.Set("address", c.DepositAddress)
+		q.Set("uint256", c.AmountAtomic)
+		return fmt.Sprintf("ethereum:%s@%d/transfer?%s", info.Contract, chainID, q.Encode()), nil
+	}
+
+	switch c.Chain {
+	case ChainTron:
+		amount, err := info.FromAtomic(c.AmountAtomic)
+		if err != nil {
+			return "", err
+		}
+		q := url.Values{}
+		q.Set("token", info.Contract)
+		q.Set("amount", amount)
+		return fmt.Sprintf("tron:%s?%s", c.DepositAddress, q.Encode()), nil
+	}
+	return "", fmt.Errorf("billingio: payment URIs are not supported on %s", c.Chain)
+}
+
+// PaymentQRCode returns a QR code encoding PaymentURI, ready to render with
+// its PNG or SVG methods.
+func (c *Checkout) PaymentQRCode() (*qrcode.Code, error) {
+	uri, err := c.PaymentURI()
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.Encode([]byte(uri), qrcode.Medium)
+}
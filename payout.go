@@ -11,9 +11,28 @@ type PayoutService struct {
 }
 
 // Create creates a new payout intent.
-func (s *PayoutService) Create(ctx context.Context, params *CreatePayoutParams) (*Payout, error) {
+//
+// The wallet address is checked and normalized for params.Chain before the
+// request is sent (see the address package). If params.IdempotencyKey is set
+// it is sent as the Idempotency-Key header; otherwise a key is generated for
+// the call.
+func (s *PayoutService) Create(ctx context.Context, params *CreatePayoutParams, opts ...RequestOption) (*Payout, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	// Send the canonical form of the address (e.g. EIP-55 checksummed)
+	// without modifying the caller's params.
+	body := params
+	if params != nil {
+		p := *params
+		p.WalletAddress = normalizeAddress(p.Chain, p.WalletAddress)
+		body = &p
+		opts = withParamsIdempotencyKey(p.IdempotencyKey, opts)
+	}
+
 	var payout Payout
-	err := s.client.post(ctx, "/payouts", params, &payout, nil)
+	err := s.client.post(ctx, "Payouts.Create", "/payouts", body, &payout, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +40,11 @@ func (s *PayoutService) Create(ctx context.Context, params *CreatePayoutParams)
 }
 
 // List returns a paginated list of payouts.
-func (s *PayoutService) List(ctx context.Context, params *ListPayoutsParams) (*PayoutList, error) {
+func (s *PayoutService) List(ctx context.Context, params *ListPayoutsParams, opts ...RequestOption) (*PayoutList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -33,7 +56,7 @@ func (s *PayoutService) List(ctx context.Context, params *ListPayoutsParams) (*P
 	path := addQueryParams("/payouts", qp)
 
 	var list PayoutList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Payouts.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,19 +64,24 @@ func (s *PayoutService) List(ctx context.Context, params *ListPayoutsParams) (*P
 }
 
 // Update updates an existing payout.
-func (s *PayoutService) Update(ctx context.Context, payoutID string, params *UpdatePayoutParams) (*Payout, error) {
+func (s *PayoutService) Update(ctx context.Context, payoutID string, params *UpdatePayoutParams, opts ...RequestOption) (*Payout, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var payout Payout
-	err := s.client.patch(ctx, fmt.Sprintf("/payouts/%s", payoutID), params, &payout)
+	err := s.client.patch(ctx, "Payouts.Update", fmt.Sprintf("/payouts/%s", payoutID), params, &payout, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &payout, nil
 }
 
-// Execute triggers execution of a pending payout.
-func (s *PayoutService) Execute(ctx context.Context, payoutID string) (*Payout, error) {
+// Execute triggers execution of a pending payout. Pass WithIdempotencyKey to
+// choose the Idempotency-Key; otherwise a key is generated for the call.
+func (s *PayoutService) Execute(ctx context.Context, payoutID string, opts ...RequestOption) (*Payout, error) {
 	var payout Payout
-	err := s.client.post(ctx, fmt.Sprintf("/payouts/%s/execute", payoutID), nil, &payout, nil)
+	err := s.client.post(ctx, "Payouts.Execute", fmt.Sprintf("/payouts/%s/execute", payoutID), nil, &payout, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,15 +90,15 @@ func (s *PayoutService) Execute(ctx context.Context, payoutID string) (*Payout,
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of payouts. See Iter for usage details.
-func (s *PayoutService) ListAutoPaginate(ctx context.Context, params *ListPayoutsParams) *Iter[Payout] {
+func (s *PayoutService) ListAutoPaginate(ctx context.Context, params *ListPayoutsParams, opts ...RequestOption) *Iter[Payout] {
 	if params == nil {
 		params = &ListPayoutsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Payout, bool, *string, error) {
+	return newIter(ctx, s.client, "Payouts", func(ctx context.Context, cursor *string) ([]Payout, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -0,0 +1,602 @@
+// Package qrcode is a small, dependency-free QR Code encoder for rendering
+// payment URIs on checkout pages.
+//
+// It encodes arbitrary bytes in byte mode (versions 1 to 40, all four error
+// correction levels) and renders the symbol as PNG or SVG:
+//
+//	code, err := qrcode.Encode([]byte(uri), qrcode.Medium)
+//	png, err := code.PNG(8)  // 8 pixels per module
+//	svg := code.SVG()
+//
+// The encoder follows ISO/IEC 18004 and is adapted from Project Nayuki's
+// reference QR Code generator.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooLong is returned when the data does not fit in a version 40 symbol
+// at the requested error correction level.
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// Level is the error correction level of a symbol. Higher levels tolerate
+// more damage at the cost of a denser symbol.
+type Level int
+
+const (
+	// Low recovers about 7% of the symbol.
+	Low Level = iota
+	// Medium recovers about 15% of the symbol. It is a good default for
+	// on-screen codes.
+	Medium
+	// Quartile recovers about 25% of the symbol.
+	Quartile
+	// High recovers about 30% of the symbol.
+	High
+)
+
+// formatBits returns the two-bit level indicator used in format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// eccCodewordsPerBlock is indexed by level then version.
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numErrorCorrectionBlocks is indexed by level then version.
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Code is an encoded QR Code symbol.
+type Code struct {
+	version int
+	size    int
+	level   Level
+	modules [][]bool // dark modules, indexed [y][x]
+
+	isFunction [][]bool // used only during encoding
+}
+
+// Encode encodes data at the given error correction level, using the
+// smallest version that fits.
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < Low || level > High {
+		return nil, fmt.Errorf("qrcode: invalid level %d", level)
+	}
+
+	version := minVersion
+	for ; ; version++ {
+		if dataBits(len(data), version) <= numDataCodewords(version, level)*8 {
+			break
+		}
+		if version == maxVersion {
+			return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+		}
+	}
+
+	// Byte-mode segment followed by terminator and padding.
+	var bb bitBuffer
+	bb.append(0x4, 4)
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	capacity := numDataCodewords(version, level) * 8
+	bb.append(0, min(4, capacity-len(bb)))
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bb)/8)
+	for i, bit := range bb {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+
+	return newCode(version, level, codewords), nil
+}
+
+// Size returns the width and height of the symbol in modules, excluding the
+// quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Version returns the symbol version, from 1 to 40.
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Dark reports whether the module at (x, y) is dark. Coordinates outside
+// the symbol are light.
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && x < c.size && y >= 0 && y < c.size && c.modules[y][x]
+}
+
+// newCode builds the symbol for the given data codewords, choosing the mask
+// with the lowest penalty.
+func newCode(version int, level Level, data []byte) *Code {
+	size := version*4 + 17
+	c := &Code{
+		version:    version,
+		size:       size,
+		level:      level,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.addECCAndInterleave(data))
+
+	best, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); minPenalty < 0 || p < minPenalty {
+			best, minPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR undoes the mask
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	c.isFunction = nil
+	return c
+}
+
+// ---------------------------------------------------------------------------
+// Function patterns
+// ---------------------------------------------------------------------------
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.size-4, 3)
+	c.drawFinderPattern(3, c.size-4)
+
+	pos := alignmentPatternPositions(c.version)
+	n := len(pos)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			// Skip the three corners occupied by finder patterns.
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(pos[i], pos[j])
+		}
+	}
+
+	c.drawFormatBits(0) // reserve the area; redrawn once the mask is chosen
+	c.drawVersion()
+}
+
+// drawFinderPattern draws a finder pattern and its separator centred on
+// (x, y).
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.size || yy < 0 || yy >= c.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws a 5x5 alignment pattern centred on (x, y).
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for mask.
+func (c *Code) drawFormatBits(mask int) {
+	data := c.level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	// First copy, around the top-left finder.
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Second copy, split between the other two finders.
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version information (version 7+).
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+	rem := c.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := bit(bits, i)
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// alignmentPatternPositions returns the centre coordinates of the alignment
+// patterns along each axis.
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	n := version/7 + 2
+	step := (version*8 + n*3 + 5) / (n*4 - 4) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, version*4+17-7; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// ---------------------------------------------------------------------------
+// Codewords
+// ---------------------------------------------------------------------------
+
+// addECCAndInterleave splits data into blocks, appends Reed-Solomon error
+// correction to each and interleaves the result.
+func (c *Code) addECCAndInterleave(data []byte) []byte {
+	numBlocks := numErrorCorrectionBlocks[c.level][c.version]
+	eccLen := eccCodewordsPerBlock[c.level][c.version]
+	rawCodewords := numRawDataModules(c.version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			n++
+		}
+		dat := append([]byte(nil), data[k:k+n]...)
+		k += n
+		ecc := reedSolomonRemainder(dat, divisor)
+		if i < numShortBlocks {
+			dat = append(dat, 0) // placeholder, skipped when interleaving
+		}
+		blocks[i] = append(dat, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codeword bits in the zigzag order.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.size - 1 - vert // upward column
+				}
+				if !c.isFunction[y][x] && i < len(data)*8 {
+					c.modules[y][x] = bit(int(data[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask XORs the data modules with mask pattern mask.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !c.isFunction[y][x] {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol per the four mask evaluation rules; lower is
+// better.
+func (c *Code) penalty() int {
+	const (
+		n1 = 3
+		n2 = 3
+		n3 = 40
+		n4 = 10
+	)
+	score := 0
+
+	// Rule 1: runs of five or more same-colour modules in a row or column.
+	for y := 0; y < c.size; y++ {
+		score += runPenalty(c.size, func(i int) bool { return c.modules[y][i] }, n1)
+	}
+	for x := 0; x < c.size; x++ {
+		score += runPenalty(c.size, func(i int) bool { return c.modules[i][x] }, n1)
+	}
+
+	// Rule 2: 2x2 blocks of one colour.
+	for y := 0; y < c.size-1; y++ {
+		for x := 0; x < c.size-1; x++ {
+			m := c.modules[y][x]
+			if m == c.modules[y][x+1] && m == c.modules[y+1][x] && m == c.modules[y+1][x+1] {
+				score += n2
+			}
+		}
+	}
+
+	// Rule 3: finder-like 1:1:3:1:1 patterns with four light modules on
+	// either side.
+	for y := 0; y < c.size; y++ {
+		score += finderPenalty(c.size, func(i int) bool { return c.Dark(i, y) }) * n3
+	}
+	for x := 0; x < c.size; x++ {
+		score += finderPenalty(c.size, func(i int) bool { return c.Dark(x, i) }) * n3
+	}
+
+	// Rule 4: imbalance between dark and light modules.
+	dark := 0
+	for _, row := range c.modules {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	total := c.size * c.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	score += max(k, 0) * n4
+
+	return score
+}
+
+// runPenalty scores runs of at least five same-colour modules along a line.
+func runPenalty(n int, at func(int) bool, weight int) int {
+	score, run := 0, 1
+	for i := 1; i <= n; i++ {
+		if i < n && at(i) == at(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += weight + run - 5
+		}
+		run = 1
+	}
+	return score
+}
+
+// finderPattern is dark-light-dark-dark-dark-light-dark.
+var finderPattern = [...]bool{true, false, true, true, true, false, true}
+
+// finderPenalty counts finder-like patterns along a line. Positions outside
+// the symbol count as light, matching the quiet zone.
+func finderPenalty(n int, at func(int) bool) int {
+	count := 0
+	for i := 0; i+len(finderPattern) <= n; i++ {
+		match := true
+		for j, want := range finderPattern {
+			if at(i+j) != want {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if lightRun(at, i-4, i) || lightRun(at, i+7, i+11) {
+			count++
+		}
+	}
+	return count
+}
+
+// lightRun reports whether every module in [from, to) is light.
+func lightRun(at func(int) bool, from, to int) bool {
+	for i := from; i < to; i++ {
+		if at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// ---------------------------------------------------------------------------
+// Capacity
+// ---------------------------------------------------------------------------
+
+// numRawDataModules returns the number of modules available for data and
+// error correction in a symbol of the given version.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		n := version/7 + 2
+		result -= (25*n-10)*n - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// numDataCodewords returns the number of data codewords in a symbol.
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+// charCountBits returns the width of the byte-mode character count field.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// dataBits returns the length in bits of a byte-mode segment of n bytes, or
+// a value exceeding any capacity if n does not fit the count field.
+func dataBits(n, version int) int {
+	cc := charCountBits(version)
+	if n >= 1<<cc {
+		return 1 << 30
+	}
+	return 4 + cc + 8*n
+}
+
+// ---------------------------------------------------------------------------
+// Reed-Solomon over GF(2^8/0x11D)
+// ---------------------------------------------------------------------------
+
+// reedSolomonDivisor returns the generator polynomial of the given degree,
+// without its leading coefficient.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies two elements of GF(2^8) modulo x^8+x^4+x^3+x^2+1.
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+// append appends the low n bits of v.
+func (b *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (v>>uint(i))&1 != 0)
+	}
+}
+
+func bit(v, i int) bool {
+	return (v>>uint(i))&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,147 @@
+package qrcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// The golden symbols below were cross-checked against the ZXing encoder,
+// which picks the same mask, and decode with ZXing.
+
+// golden1M is "hello, world" at level Medium: version 1, a single block.
+var golden1M = []string{
+	"#######..#.##.#######",
+	"#.....#.##..#.#.....#",
+	"#.###.#..#..#.#.###.#",
+	"#.###.#...##..#.###.#",
+	"#.###.#.#..##.#.###.#",
+	"#.....#....#..#.....#",
+	"#######.#.#.#.#######",
+	"..........#..........",
+	"#.#.#.#..#..#...#..#.",
+	"#.##...###.#....#..##",
+	".#..####.###.#.######",
+	"####.#.######..#...#.",
+	".######.#.##....#....",
+	"........##.#..###.###",
+	"#######..#..##..#.###",
+	"#.....#....#...#...#.",
+	"#.###.#.##.###.#...#.",
+	"#.###.#..#.###.##.##.",
+	"#.###.#.#..##...#.#.#",
+	"#.....#..#.#....#..#.",
+	"#######.####...#...##",
+}
+
+// golden5Q is a Tron payment URI at level Quartile: version 5, with two
+// blocks of 15 data codewords and two of 16.
+var golden5Q = []string{
+	"#######...####.#.##.#.#.###...#######",
+	"#.....#.###...####...#.####.#.#.....#",
+	"#.###.#.##..#.###.#...#..#..#.#.###.#",
+	"#.###.#...##.#.#..#...#.##.##.#.###.#",
+	"#.###.#.....#..#...#....#..#..#.###.#",
+	"#.....#..#.##.##.####.#.##..#.#.....#",
+	"#######.#.#.#.#.#.#.#.#.#.#.#.#######",
+	"............#.#.###....#...#.........",
+	".###.##..#.#.####.###...##..#.....##.",
+	"...#.#.##....#..##.#...#..###......#.",
+	"#..#..#..##.#.#.#####.##.#.#..#......",
+	".#.#.#...#.#..#...#.....####..##..###",
+	"#.###.###.#.####..###.#.#.#...#.#.##.",
+	".###.#.##.#..##.####.#..#####.####..#",
+	"##..#.####.#.........#..##..#..##.##.",
+	"##.....#.#..##.##..##...#..###..#..##",
+	"###.#.#.##...#.######.##...##...####.",
+	"#..#....##.#..###.#...#.####..#....##",
+	"#..#.###...##....##.########.##..#.##",
+	"...#.#.#####...##.###.#.#.#...####..#",
+	"##...##.#..#.##....#...#..##.#.##.#..",
+	"###.##...####.##....#..#.####..#...#.",
+	".#.#.##.###.###.#.#.#.####....##.....",
+	"#..#....#..##.#...##..###.....#.###.#",
+	"#.#.####.#.#.#.##..#...#..##..###..#.",
+	".#.#.#.#..#.#.#...#.###..##...#.#####",
+	".####.#......##...##.#..#.#...#..###.",
+	"#.#.#...######...###.##.#..##....#..#",
+	"..##.##..#..##...######.#...#########",
+	"........##...#.....#...##...#...#.##.",
+	"#######..###.#.###..##..##.##.#.#####",
+	"#.....#.#..#.#.##..##.##..###...##..#",
+	"#.###.#..##.##...#....#####.#####...#",
+	"#.###.#.#..#..#...#.###.#.#######..#.",
+	"#.###.#.#.#...#.##.##.###...#..#####.",
+	"#.....#.#####.##.#..#....#.#..#.###..",
+	"#######...###.#.#.##..#..#.#.##....##",
+}
+
+func TestEncodeGolden(t *testing.T) {
+	tests := []struct {
+		data    string
+		level   Level
+		version int
+		want    []string
+	}{
+		{"hello, world", Medium, 1, golden1M},
+		{"tron:TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t?amount=12.5", Quartile, 5, golden5Q},
+	}
+	for _, tt := range tests {
+		c, err := Encode([]byte(tt.data), tt.level)
+		if err != nil {
+			t.Fatalf("Encode(%q): %v", tt.data, err)
+		}
+		if c.Version() != tt.version {
+			t.Errorf("Encode(%q) version = %d, want %d", tt.data, c.Version(), tt.version)
+		}
+		if got := render(c); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("Encode(%q) =\n%s\nwant\n%s", tt.data, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
+
+func TestEncodeCapacity(t *testing.T) {
+	tests := []struct {
+		n       int
+		level   Level
+		version int
+	}{
+		{17, Low, 1},
+		{18, Low, 2},
+		{7, High, 1},
+		{8, High, 2},
+		{2953, Low, 40},
+		{1273, High, 40},
+	}
+	for _, tt := range tests {
+		c, err := Encode(make([]byte, tt.n), tt.level)
+		if err != nil {
+			t.Errorf("Encode(%d bytes, %d): %v", tt.n, tt.level, err)
+			continue
+		}
+		if c.Version() != tt.version || c.Size() != 17+4*tt.version {
+			t.Errorf("Encode(%d bytes, %d) version = %d size = %d, want version %d", tt.n, tt.level, c.Version(), c.Size(), tt.version)
+		}
+	}
+
+	if _, err := Encode(make([]byte, 2954), Low); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Encode(2954 bytes) error = %v, want ErrTooLong", err)
+	}
+}
+
+// render draws c as rows of '#' (dark) and '.' (light).
+func render(c *Code) []string {
+	rows := make([]string, c.Size())
+	for y := range rows {
+		var b strings.Builder
+		for x := 0; x < c.Size(); x++ {
+			if c.Dark(x, y) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		rows[y] = b.String()
+	}
+	return rows
+}
@@ -0,0 +1,99 @@
+package billingio
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit describes the rate-limit state reported by the API in the
+// X-RateLimit-* response headers.
+type RateLimit struct {
+	// Limit is the maximum number of requests allowed in the current window.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is when the current window ends and Remaining is replenished.
+	Reset time.Time
+}
+
+// parseRateLimit extracts the X-RateLimit-* headers. It returns nil if the
+// response carries none of them.
+func parseRateLimit(h http.Header) *RateLimit {
+	limit := h.Get("X-RateLimit-Limit")
+	remaining := h.Get("X-RateLimit-Remaining")
+	reset := h.Get("X-RateLimit-Reset")
+	if limit == "" && remaining == "" && reset == "" {
+		return nil
+	}
+
+	rl := &RateLimit{}
+	rl.Limit, _ = strconv.Atoi(strings.TrimSpace(limit))
+	rl.Remaining, _ = strconv.Atoi(strings.TrimSpace(remaining))
+	if secs, err := strconv.ParseInt(strings.TrimSpace(reset), 10, 64); err == nil {
+		rl.Reset = time.Unix(secs, 0)
+	}
+	return rl
+}
+
+// parseRetryAfter interprets a Retry-After header, which is either a number
+// of seconds or an HTTP date. It returns 0 if the header is absent or invalid.
+func parseRetryAfter(h http.Header, now time.Time) time.Duration {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// RateLimit returns the rate-limit state from the most recent API response,
+// or nil if no response has carried rate-limit headers yet.
+func (c *Client) RateLimit() *RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rateLimit == nil {
+		return nil
+	}
+	rl := *c.rateLimit
+	return &rl
+}
+
+// recordRateLimit stores rl as the most recently observed rate-limit state.
+func (c *Client) recordRateLimit(rl *RateLimit) {
+	if rl == nil {
+		return
+	}
+	c.mu.Lock()
+	c.rateLimit = rl
+	c.mu.Unlock()
+}
+
+// serverRequestedDelay returns how long the server asked us to wait before
+// retrying, derived from Retry-After or, for exhausted rate limits, from
+// X-RateLimit-Reset. It returns 0 if the server expressed no preference.
+func serverRequestedDelay(apiErr *Error, now time.Time) time.Duration {
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	rl := apiErr.RateLimit
+	if apiErr.StatusCode == http.StatusTooManyRequests && rl != nil && rl.Remaining == 0 && !rl.Reset.IsZero() {
+		if d := rl.Reset.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package billingio
+
+import (
+	"net/http"
+	"time"
+)
+
+// accountHeader is the HTTP header selecting the connected account a request
+// acts on behalf of.
+const accountHeader = "Billing-Account"
+
+// RequestOption configures a single API call. Every service method accepts a
+// variadic list of request options, so one shared *Client can serve requests
+// for many tenants:
+//
+//	checkout, err := client.Checkouts.Get(ctx, "co_abc123",
+//	    billingio.WithAPIKey(tenant.APIKey),
+//	    billingio.WithTimeout(5*time.Second),
+//	)
+//
+// Options are applied in order; later options override earlier ones.
+type RequestOption func(*requestConfig)
+
+// requestConfig is the per-call configuration built from RequestOptions.
+type requestConfig struct {
+	header     http.Header
+	apiKey     string
+	timeout    time.Duration
+	maxRetries *int
+	response   *Response
+}
+
+// newRequestConfig applies opts on top of an empty configuration.
+func newRequestConfig(opts []RequestOption) *requestConfig {
+	cfg := &requestConfig{header: make(http.Header)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return cfg
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header for the call, overriding
+// both the params-level key and the automatically generated one.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(cfg *requestConfig) {
+		if key != "" {
+			cfg.header.Set(idempotencyKeyHeader, key)
+		}
+	}
+}
+
+// WithHeader sets an additional HTTP header on the call.
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.header.Set(key, value)
+	}
+}
+
+// WithAPIKey authenticates the call with key instead of the client's key.
+func WithAPIKey(key string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.apiKey = key
+	}
+}
+
+// WithAccount makes the call on behalf of the given connected account by
+// setting the Billing-Account header.
+func WithAccount(accountID string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.header.Set(accountHeader, accountID)
+	}
+}
+
+// WithTimeout bounds the whole call, including any retries.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.timeout = d
+	}
+}
+
+// WithRequestMaxRetries overrides the client's RetryPolicy.MaxRetries for
+// the call. Use 0 to disable retries.
+func WithRequestMaxRetries(n int) RequestOption {
+	return func(cfg *requestConfig) {
+		if n < 0 {
+			n = 0
+		}
+		cfg.maxRetries = &n
+	}
+}
+
+// withParamsIdempotencyKey prepends the idempotency key taken from a params
+// struct to opts, so that an explicit WithIdempotencyKey still wins.
+func withParamsIdempotencyKey(key string, opts []RequestOption) []RequestOption {
+	if key == "" {
+		return opts
+	}
+	return append([]RequestOption{WithIdempotencyKey(key)}, opts...)
+}
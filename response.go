@@ -0,0 +1,57 @@
+package billingio
+
+import (
+	"net/http"
+	"time"
+)
+
+// requestIDHeader is the HTTP header carrying the API's request identifier.
+const requestIDHeader = "X-Request-Id"
+
+// Response holds metadata about the HTTP response to an API call. Use
+// WithResponseInto to capture it:
+//
+//	var resp billingio.Response
+//	checkout, err := client.Checkouts.Get(ctx, "co_abc123",
+//	    billingio.WithResponseInto(&resp),
+//	)
+//	log.Printf("request %s took %s", resp.RequestID, resp.Latency)
+type Response struct {
+	// RequestID is the value of the X-Request-Id header. Quote it when
+	// contacting billing.io support.
+	RequestID string
+
+	// StatusCode is the HTTP status code of the final attempt.
+	StatusCode int
+
+	// Header holds the response headers of the final attempt.
+	Header http.Header
+
+	// RateLimit holds the X-RateLimit-* headers, if present.
+	RateLimit *RateLimit
+
+	// Latency is the total duration of the call, including retries.
+	Latency time.Duration
+
+	// Attempts is the number of HTTP requests made for the call.
+	Attempts int
+}
+
+// WithResponseInto stores the metadata of the call's final HTTP response in
+// dst. dst is filled in even when the call returns an error, provided the
+// server responded.
+func WithResponseInto(dst *Response) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.response = dst
+	}
+}
+
+// newResponse builds the metadata for a single HTTP response.
+func newResponse(resp *http.Response) *Response {
+	return &Response{
+		RequestID:  resp.Header.Get(requestIDHeader),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RateLimit:  parseRateLimit(resp.Header),
+	}
+}
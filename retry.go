@@ -0,0 +1,168 @@
+package billingio
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 2
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 8 * time.Second
+	defaultMaxRetryAfter  = time.Minute
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// Network errors, 409 (lock conflict), 429 (rate limited) and 5xx responses
+// are retried with exponential backoff and jitter. When the server sends a
+// Retry-After header (or an exhausted X-RateLimit-Reset on a 429), the client
+// waits for the requested duration instead. POST and PATCH requests are only
+// retried when they carry an Idempotency-Key header.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	// Zero disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the base delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter is the longest server-requested delay the client will
+	// wait for. If the server asks for more, the error is returned instead.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by New.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		MaxRetryAfter:  defaultMaxRetryAfter,
+	}
+}
+
+// WithMaxRetries sets the maximum number of retries per request.
+// Use 0 to disable retries entirely.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		if n < 0 {
+			n = 0
+		}
+		c.retryPolicy.MaxRetries = n
+	}
+}
+
+// WithRetryPolicy replaces the client's retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.MaxRetries < 0 {
+			p.MaxRetries = 0
+		}
+		if p.InitialBackoff <= 0 {
+			p.InitialBackoff = defaultInitialBackoff
+		}
+		if p.MaxBackoff < p.InitialBackoff {
+			p.MaxBackoff = p.InitialBackoff
+		}
+		if p.MaxRetryAfter <= 0 {
+			p.MaxRetryAfter = defaultMaxRetryAfter
+		}
+		c.retryPolicy = p
+	}
+}
+
+// backoff returns the delay before retry number attempt (starting at 1),
+// using exponential growth with "equal jitter": half of the delay is fixed
+// and the other half is random.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// delay returns how long to wait before retry number attempt after err.
+// It reports false if the server asked for a longer pause than the policy
+// allows, in which case the caller should give up.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if d := serverRequestedDelay(apiErr, time.Now()); d > 0 {
+			return d, d <= p.MaxRetryAfter
+		}
+	}
+	return p.backoff(attempt), true
+}
+
+// isIdempotentMethod reports whether method can be safely repeated without
+// an idempotency key.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a request that failed with err may be retried.
+func shouldRetry(ctx context.Context, method string, hasIdempotencyKey bool, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if !isIdempotentMethod(method) && !hasIdempotencyKey {
+		return false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusConflict,
+			apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode >= 500:
+			return true
+		}
+		return false
+	}
+
+	// Only transport failures are retryable; marshalling or decoding
+	// errors will fail the same way on every attempt.
+	var netErr *requestError
+	return errors.As(err, &netErr)
+}
+
+// requestError wraps a transport-level failure from the HTTP client.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return "billingio: request failed: " + e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
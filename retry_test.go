@@ -0,0 +1,195 @@
+package billingio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// attemptServer answers each request with the next response in replies and
+// repeats the last one when they run out. It records the requests it saw.
+type attemptServer struct {
+	mu      sync.Mutex
+	replies []reply
+	seen    []*http.Request
+}
+
+type reply struct {
+	status int
+	header map[string]string
+}
+
+func (s *attemptServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	i := min(len(s.seen), len(s.replies)-1)
+	s.seen = append(s.seen, r)
+	rep := s.replies[i]
+	s.mu.Unlock()
+
+	for k, v := range rep.header {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rep.status)
+	if rep.status >= 400 {
+		w.Write([]byte(`{"error":{"type":"api_error","code":"test","message":"test error"}}`))
+		return
+	}
+	w.Write([]byte(`{"payout_id":"po_1","checkout_id":"co_1"}`))
+}
+
+func (s *attemptServer) requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen
+}
+
+// fastRetries keeps backoff short so tests that do not exercise
+// Retry-After run quickly.
+var fastRetries = WithRetryPolicy(RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond})
+
+func newAttemptClient(t *testing.T, replies []reply, opts ...Option) (*Client, *attemptServer) {
+	t.Helper()
+	srv := &attemptServer{replies: replies}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return New("sk_test", append([]Option{WithBaseURL(ts.URL)}, opts...)...), srv
+}
+
+func TestRetryHonorsRetryAfterWithOneIdempotencyKey(t *testing.T) {
+	limited := reply{http.StatusTooManyRequests, map[string]string{"Retry-After": "1"}}
+	client, srv := newAttemptClient(t, []reply{limited, limited, {status: http.StatusOK}})
+
+	var resp Response
+	start := time.Now()
+	_, err := client.Payouts.Execute(context.Background(), "po_1", WithResponseInto(&resp))
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.requests()
+	if len(reqs) != 3 || resp.Attempts != 3 {
+		t.Fatalf("%d requests, Attempts = %d, want 3", len(reqs), resp.Attempts)
+	}
+	// The default backoff starts at 500ms; two waits of a second each show
+	// that Retry-After was used instead.
+	if elapsed < 2*time.Second {
+		t.Errorf("call took %s, want at least 2s", elapsed)
+	}
+	key := reqs[0].Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		t.Fatal("no Idempotency-Key on the first attempt")
+	}
+	for i, r := range reqs {
+		if got := r.Header.Get(idempotencyKeyHeader); got != key {
+			t.Errorf("attempt %d: Idempotency-Key %q, want %q", i+1, got, key)
+		}
+	}
+}
+
+func TestRetryUsesExplicitIdempotencyKey(t *testing.T) {
+	client, srv := newAttemptClient(t, []reply{{status: http.StatusBadGateway}, {status: http.StatusOK}}, fastRetries)
+
+	_, err := client.SubscriptionRenewals.Retry(context.Background(), "ren_1", WithIdempotencyKey("renewal-retry-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.requests()
+	if len(reqs) != 2 {
+		t.Fatalf("%d requests, want 2", len(reqs))
+	}
+	for i, r := range reqs {
+		if got := r.Header.Get(idempotencyKeyHeader); got != "renewal-retry-1" {
+			t.Errorf("attempt %d: Idempotency-Key %q", i+1, got)
+		}
+	}
+
+	// Separate calls get separate generated keys.
+	client.Payouts.Execute(context.Background(), "po_1")
+	client.Payouts.Execute(context.Background(), "po_1")
+	reqs = srv.requests()
+	if a, b := reqs[2].Header.Get(idempotencyKeyHeader), reqs[3].Header.Get(idempotencyKeyHeader); a == b {
+		t.Errorf("two calls shared the key %q", a)
+	}
+}
+
+func TestRetryAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c *Client) error
+		status   int
+		attempts int
+	}{
+		{"5xx up to MaxRetries", executePayout, http.StatusServiceUnavailable, 3},
+		{"409 lock conflict", executePayout, http.StatusConflict, 3},
+		{"4xx", executePayout, http.StatusBadRequest, 1},
+		{"404", getCheckout, http.StatusNotFound, 1},
+		{"GET 5xx", getCheckout, http.StatusInternalServerError, 3},
+		{"PATCH without key", updatePayout, http.StatusServiceUnavailable, 1},
+	}
+	for _, tt := range tests {
+		client, srv := newAttemptClient(t, []reply{{status: tt.status}}, fastRetries)
+		err := tt.call(client)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("%s: err = %v, want status %d", tt.name, err, tt.status)
+		}
+		if n := len(srv.requests()); n != tt.attempts {
+			t.Errorf("%s: %d attempts, want %d", tt.name, n, tt.attempts)
+		}
+	}
+}
+
+func TestRetryGivesUpOnLongRetryAfter(t *testing.T) {
+	limited := reply{http.StatusTooManyRequests, map[string]string{"Retry-After": "120"}}
+	client, srv := newAttemptClient(t, []reply{limited})
+
+	err := executePayout(client)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 2*time.Minute {
+		t.Fatalf("err = %v, want RetryAfter of 2m", err)
+	}
+	if n := len(srv.requests()); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	limited := reply{http.StatusTooManyRequests, map[string]string{"Retry-After": "30"}}
+	client, srv := newAttemptClient(t, []reply{limited})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Payouts.Execute(ctx, "po_1")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("err = %v, want the 429", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call took %s after the context was done", elapsed)
+	}
+	if n := len(srv.requests()); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func executePayout(c *Client) error {
+	_, err := c.Payouts.Execute(context.Background(), "po_1")
+	return err
+}
+
+func getCheckout(c *Client) error {
+	_, err := c.Checkouts.Get(context.Background(), "co_1")
+	return err
+}
+
+func updatePayout(c *Client) error {
+	_, err := c.Payouts.Update(context.Background(), "po_1", &UpdatePayoutParams{Metadata: map[string]string{"a": "b"}})
+	return err
+}
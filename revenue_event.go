@@ -8,7 +8,11 @@ type RevenueEventService struct {
 }
 
 // List returns a paginated list of revenue events.
-func (s *RevenueEventService) List(ctx context.Context, params *ListRevenueEventsParams) (*RevenueEventList, error) {
+func (s *RevenueEventService) List(ctx context.Context, params *ListRevenueEventsParams, opts ...RequestOption) (*RevenueEventList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -20,7 +24,7 @@ func (s *RevenueEventService) List(ctx context.Context, params *ListRevenueEvent
 	path := addQueryParams("/revenue/events", qp)
 
 	var list RevenueEventList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "RevenueEvents.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -28,16 +32,20 @@ func (s *RevenueEventService) List(ctx context.Context, params *ListRevenueEvent
 }
 
 // Accounting returns an aggregated revenue accounting summary.
-func (s *RevenueEventService) Accounting(ctx context.Context, params *AccountingSummaryParams) (*AccountingSummary, error) {
+func (s *RevenueEventService) Accounting(ctx context.Context, params *AccountingSummaryParams, opts ...RequestOption) (*AccountingSummary, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
-		qp["period_start"] = strOrEmpty(params.PeriodStart)
-		qp["period_end"] = strOrEmpty(params.PeriodEnd)
+		qp["period_start"] = timeToString(params.PeriodStart)
+		qp["period_end"] = timeToString(params.PeriodEnd)
 	}
 	path := addQueryParams("/revenue/accounting", qp)
 
 	var summary AccountingSummary
-	err := s.client.get(ctx, path, &summary)
+	err := s.client.get(ctx, "RevenueEvents.Accounting", path, &summary, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,15 +54,15 @@ func (s *RevenueEventService) Accounting(ctx context.Context, params *Accounting
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of revenue events. See Iter for usage details.
-func (s *RevenueEventService) ListAutoPaginate(ctx context.Context, params *ListRevenueEventsParams) *Iter[RevenueEvent] {
+func (s *RevenueEventService) ListAutoPaginate(ctx context.Context, params *ListRevenueEventsParams, opts ...RequestOption) *Iter[RevenueEvent] {
 	if params == nil {
 		params = &ListRevenueEventsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]RevenueEvent, bool, *string, error) {
+	return newIter(ctx, s.client, "RevenueEvents", func(ctx context.Context, cursor *string) ([]RevenueEvent, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -8,7 +8,11 @@ type SettlementService struct {
 }
 
 // List returns a paginated list of settlements.
-func (s *SettlementService) List(ctx context.Context, params *ListSettlementsParams) (*SettlementList, error) {
+func (s *SettlementService) List(ctx context.Context, params *ListSettlementsParams, opts ...RequestOption) (*SettlementList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -18,7 +22,7 @@ func (s *SettlementService) List(ctx context.Context, params *ListSettlementsPar
 	path := addQueryParams("/payouts/settlements", qp)
 
 	var list SettlementList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Settlements.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -27,15 +31,15 @@ func (s *SettlementService) List(ctx context.Context, params *ListSettlementsPar
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of settlements. See Iter for usage details.
-func (s *SettlementService) ListAutoPaginate(ctx context.Context, params *ListSettlementsParams) *Iter[Settlement] {
+func (s *SettlementService) ListAutoPaginate(ctx context.Context, params *ListSettlementsParams, opts ...RequestOption) *Iter[Settlement] {
 	if params == nil {
 		params = &ListSettlementsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Settlement, bool, *string, error) {
+	return newIter(ctx, s.client, "Settlements", func(ctx context.Context, cursor *string) ([]Settlement, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
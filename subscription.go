@@ -11,9 +11,20 @@ type SubscriptionService struct {
 }
 
 // Create creates a new subscription.
-func (s *SubscriptionService) Create(ctx context.Context, params *CreateSubscriptionParams) (*Subscription, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *SubscriptionService) Create(ctx context.Context, params *CreateSubscriptionParams, opts ...RequestOption) (*Subscription, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var sub Subscription
-	err := s.client.post(ctx, "/subscriptions", params, &sub, nil)
+	err := s.client.post(ctx, "Subscriptions.Create", "/subscriptions", params, &sub, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +32,11 @@ func (s *SubscriptionService) Create(ctx context.Context, params *CreateSubscrip
 }
 
 // List returns a paginated list of subscriptions.
-func (s *SubscriptionService) List(ctx context.Context, params *ListSubscriptionsParams) (*SubscriptionList, error) {
+func (s *SubscriptionService) List(ctx context.Context, params *ListSubscriptionsParams, opts ...RequestOption) (*SubscriptionList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -35,7 +50,7 @@ func (s *SubscriptionService) List(ctx context.Context, params *ListSubscription
 	path := addQueryParams("/subscriptions", qp)
 
 	var list SubscriptionList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Subscriptions.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,9 +58,13 @@ func (s *SubscriptionService) List(ctx context.Context, params *ListSubscription
 }
 
 // Update updates an existing subscription.
-func (s *SubscriptionService) Update(ctx context.Context, subscriptionID string, params *UpdateSubscriptionParams) (*Subscription, error) {
+func (s *SubscriptionService) Update(ctx context.Context, subscriptionID string, params *UpdateSubscriptionParams, opts ...RequestOption) (*Subscription, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var sub Subscription
-	err := s.client.patch(ctx, fmt.Sprintf("/subscriptions/%s", subscriptionID), params, &sub)
+	err := s.client.patch(ctx, "Subscriptions.Update", fmt.Sprintf("/subscriptions/%s", subscriptionID), params, &sub, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,15 +73,15 @@ func (s *SubscriptionService) Update(ctx context.Context, subscriptionID string,
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of subscriptions. See Iter for usage details.
-func (s *SubscriptionService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionsParams) *Iter[Subscription] {
+func (s *SubscriptionService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionsParams, opts ...RequestOption) *Iter[Subscription] {
 	if params == nil {
 		params = &ListSubscriptionsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]Subscription, bool, *string, error) {
+	return newIter(ctx, s.client, "Subscriptions", func(ctx context.Context, cursor *string) ([]Subscription, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -11,9 +11,20 @@ type SubscriptionPlanService struct {
 }
 
 // Create creates a new subscription plan.
-func (s *SubscriptionPlanService) Create(ctx context.Context, params *CreateSubscriptionPlanParams) (*SubscriptionPlan, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *SubscriptionPlanService) Create(ctx context.Context, params *CreateSubscriptionPlanParams, opts ...RequestOption) (*SubscriptionPlan, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var plan SubscriptionPlan
-	err := s.client.post(ctx, "/subscriptions/plans", params, &plan, nil)
+	err := s.client.post(ctx, "SubscriptionPlans.Create", "/subscriptions/plans", params, &plan, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +32,11 @@ func (s *SubscriptionPlanService) Create(ctx context.Context, params *CreateSubs
 }
 
 // List returns a paginated list of subscription plans.
-func (s *SubscriptionPlanService) List(ctx context.Context, params *ListSubscriptionPlansParams) (*SubscriptionPlanList, error) {
+func (s *SubscriptionPlanService) List(ctx context.Context, params *ListSubscriptionPlansParams, opts ...RequestOption) (*SubscriptionPlanList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -33,7 +48,7 @@ func (s *SubscriptionPlanService) List(ctx context.Context, params *ListSubscrip
 	path := addQueryParams("/subscriptions/plans", qp)
 
 	var list SubscriptionPlanList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "SubscriptionPlans.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +56,13 @@ func (s *SubscriptionPlanService) List(ctx context.Context, params *ListSubscrip
 }
 
 // Update updates an existing subscription plan.
-func (s *SubscriptionPlanService) Update(ctx context.Context, planID string, params *UpdateSubscriptionPlanParams) (*SubscriptionPlan, error) {
+func (s *SubscriptionPlanService) Update(ctx context.Context, planID string, params *UpdateSubscriptionPlanParams, opts ...RequestOption) (*SubscriptionPlan, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var plan SubscriptionPlan
-	err := s.client.patch(ctx, fmt.Sprintf("/subscriptions/plans/%s", planID), params, &plan)
+	err := s.client.patch(ctx, "SubscriptionPlans.Update", fmt.Sprintf("/subscriptions/plans/%s", planID), params, &plan, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,15 +71,15 @@ func (s *SubscriptionPlanService) Update(ctx context.Context, planID string, par
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of subscription plans. See Iter for usage details.
-func (s *SubscriptionPlanService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionPlansParams) *Iter[SubscriptionPlan] {
+func (s *SubscriptionPlanService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionPlansParams, opts ...RequestOption) *Iter[SubscriptionPlan] {
 	if params == nil {
 		params = &ListSubscriptionPlansParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]SubscriptionPlan, bool, *string, error) {
+	return newIter(ctx, s.client, "SubscriptionPlans", func(ctx context.Context, cursor *string) ([]SubscriptionPlan, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
@@ -11,7 +11,11 @@ type SubscriptionRenewalService struct {
 }
 
 // List returns a paginated list of subscription renewals.
-func (s *SubscriptionRenewalService) List(ctx context.Context, params *ListSubscriptionRenewalsParams) (*SubscriptionRenewalList, error) {
+func (s *SubscriptionRenewalService) List(ctx context.Context, params *ListSubscriptionRenewalsParams, opts ...RequestOption) (*SubscriptionRenewalList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -24,17 +28,18 @@ func (s *SubscriptionRenewalService) List(ctx context.Context, params *ListSubsc
 	path := addQueryParams("/subscriptions/renewals", qp)
 
 	var list SubscriptionRenewalList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "SubscriptionRenewals.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &list, nil
 }
 
-// Retry retries a failed subscription renewal.
-func (s *SubscriptionRenewalService) Retry(ctx context.Context, renewalID string) (*SubscriptionRenewal, error) {
+// Retry retries a failed subscription renewal. Pass WithIdempotencyKey to
+// choose the Idempotency-Key; otherwise a key is generated for the call.
+func (s *SubscriptionRenewalService) Retry(ctx context.Context, renewalID string, opts ...RequestOption) (*SubscriptionRenewal, error) {
 	var renewal SubscriptionRenewal
-	err := s.client.post(ctx, fmt.Sprintf("/subscriptions/renewals/%s/retry", renewalID), nil, &renewal, nil)
+	err := s.client.post(ctx, "SubscriptionRenewals.Retry", fmt.Sprintf("/subscriptions/renewals/%s/retry", renewalID), nil, &renewal, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,15 +48,15 @@ func (s *SubscriptionRenewalService) Retry(ctx context.Context, renewalID string
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of subscription renewals. See Iter for usage details.
-func (s *SubscriptionRenewalService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionRenewalsParams) *Iter[SubscriptionRenewal] {
+func (s *SubscriptionRenewalService) ListAutoPaginate(ctx context.Context, params *ListSubscriptionRenewalsParams, opts ...RequestOption) *Iter[SubscriptionRenewal] {
 	if params == nil {
 		params = &ListSubscriptionRenewalsParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]SubscriptionRenewal, bool, *string, error) {
+	return newIter(ctx, s.client, "SubscriptionRenewals", func(ctx context.Context, cursor *string) ([]SubscriptionRenewal, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.List(ctx, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
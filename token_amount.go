@@ -0,0 +1,66 @@
+package billingio
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/billing-io/billing-go/tokens"
+)
+
+// lookupToken returns the deployment of token on chain.
+func lookupToken(chain Chain, token Token) (tokens.Info, error) {
+	info, ok := tokens.Lookup(string(chain), string(token))
+	if !ok {
+		return tokens.Info{}, fmt.Errorf("billingio: %w: %s/%s", tokens.ErrUnsupported, chain, token)
+	}
+	return info, nil
+}
+
+// TokenInfo returns the decimals and contract address of the checkout's
+// token on its chain.
+func (c *Checkout) TokenInfo() (tokens.Info, error) {
+	return lookupToken(c.Chain, c.Token)
+}
+
+// AmountAtomicInt returns AmountAtomic as an integer.
+func (c *Checkout) AmountAtomicInt() (*big.Int, error) {
+	n, ok := new(big.Int).SetString(c.AmountAtomic, 10)
+	if !ok {
+		return nil, fmt.Errorf("billingio: invalid amount_atomic %q", c.AmountAtomic)
+	}
+	return n, nil
+}
+
+// TokenAmount returns AmountAtomic as a human-readable token amount, e.g.
+// "49.99" for 49990000 atomic units of a 6-decimal token.
+func (c *Checkout) TokenAmount() (string, error) {
+	info, err := c.TokenInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.FromAtomic(c.AmountAtomic)
+}
+
+// ToAtomic converts m to atomic units of token on chain, assuming the 1:1
+// USD peg used to price checkouts.
+func (m Money) ToAtomic(chain Chain, token Token) (string, error) {
+	info, err := lookupToken(chain, token)
+	if err != nil {
+		return "", err
+	}
+	return info.USDToAtomic(m.String())
+}
+
+// MoneyFromAtomic converts atomic units of token on chain to a USD amount,
+// assuming a 1:1 peg. It fails if the amount is not a whole number of cents.
+func MoneyFromAtomic(atomic string, chain Chain, token Token) (Money, error) {
+	info, err := lookupToken(chain, token)
+	if err != nil {
+		return Money{}, err
+	}
+	usd, err := info.AtomicToUSD(atomic)
+	if err != nil {
+		return Money{}, err
+	}
+	return ParseMoney(usd)
+}
@@ -0,0 +1,166 @@
+// Package tokens describes the stablecoins supported by billing.io on each
+// chain and converts between human-readable and atomic token amounts.
+//
+// Atomic amounts are the integer strings used on-chain and in
+// Checkout.AmountAtomic: an amount of 49.99 USDT on Tron (6 decimals) is
+// "49990000" atomic units.
+//
+//	info, ok := tokens.Lookup("tron", "USDT")
+//	atomic, err := info.ToAtomic("49.99") // "49990000"
+//	human, err := info.FromAtomic(atomic) // "49.99"
+//
+// The package has no dependency on the billingio package, so chain and token
+// identifiers are passed as plain strings (e.g. string(billingio.ChainTron)).
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrUnsupported is returned for chain/token pairs not supported by billing.io.
+var ErrUnsupported = errors.New("tokens: unsupported chain/token pair")
+
+// Info describes a token deployment on a specific chain.
+type Info struct {
+	// Chain is the billing.io chain identifier (e.g. "tron", "arbitrum").
+	Chain string
+
+	// Symbol is the token symbol (e.g. "USDT").
+	Symbol string
+
+	// Decimals is the number of decimal places of one token unit.
+	Decimals int
+
+	// Contract is the token contract address on Chain.
+	Contract string
+}
+
+// registry lists every supported deployment, keyed by chain then symbol.
+var registry = map[string]map[string]Info{
+	"tron": {
+		"USDT": {Chain: "tron", Symbol: "USDT", Decimals: 6, Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		"USDC": {Chain: "tron", Symbol: "USDC", Decimals: 6, Contract: "TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8"},
+	},
+	"arbitrum": {
+		"USDT": {Chain: "arbitrum", Symbol: "USDT", Decimals: 6, Contract: "0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9"},
+		"USDC": {Chain: "arbitrum", Symbol: "USDC", Decimals: 6, Contract: "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"},
+	},
+}
+
+// Lookup returns the deployment of symbol on chain.
+func Lookup(chain, symbol string) (Info, bool) {
+	info, ok := registry[chain][symbol]
+	return info, ok
+}
+
+// All returns every supported deployment.
+func All() []Info {
+	var out []Info
+	for _, chain := range []string{"tron", "arbitrum"} {
+		for _, symbol := range []string{"USDT", "USDC"} {
+			if info, ok := registry[chain][symbol]; ok {
+				out = append(out, info)
+			}
+		}
+	}
+	return out
+}
+
+// ToAtomic converts a human-readable amount such as "49.99" to atomic units
+// of this token. Amounts finer than the token's precision are rejected.
+func (i Info) ToAtomic(amount string) (string, error) {
+	n, err := ToAtomic(amount, i.Decimals)
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// FromAtomic converts an atomic integer string to a human-readable amount
+// of this token, without trailing zeros (e.g. "49990000" -> "49.99").
+func (i Info) FromAtomic(atomic string) (string, error) {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(atomic), 10)
+	if !ok {
+		return "", fmt.Errorf("tokens: invalid atomic amount %q", atomic)
+	}
+	return FromAtomic(n, i.Decimals), nil
+}
+
+// USDToAtomic converts a USD amount to atomic units, assuming the 1:1 peg
+// billing.io uses to price stablecoin checkouts.
+func (i Info) USDToAtomic(usd string) (string, error) {
+	return i.ToAtomic(usd)
+}
+
+// AtomicToUSD converts atomic units to a USD amount with two decimal
+// places, assuming a 1:1 peg. Sub-cent remainders are rejected rather than
+// rounded.
+func (i Info) AtomicToUSD(atomic string) (string, error) {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(atomic), 10)
+	if !ok {
+		return "", fmt.Errorf("tokens: invalid atomic amount %q", atomic)
+	}
+	r := new(big.Rat).SetFrac(n, pow10(i.Decimals))
+	cents := new(big.Rat).Mul(r, big.NewRat(100, 1))
+	if !cents.IsInt() {
+		return "", fmt.Errorf("tokens: atomic amount %s is not a whole number of cents", atomic)
+	}
+	return r.FloatString(2), nil
+}
+
+// ToAtomic converts a decimal amount to an integer number of atomic units
+// given the token's decimals. It returns an error if amount is not a plain
+// decimal number or has more fractional digits than decimals allows.
+func ToAtomic(amount string, decimals int) (*big.Int, error) {
+	s := strings.TrimSpace(amount)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return nil, fmt.Errorf("tokens: invalid amount %q", amount)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("tokens: amount %q has more than %d decimal places", amount, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("tokens: invalid amount %q", amount)
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// FromAtomic formats an integer number of atomic units as a decimal amount
+// without trailing zeros.
+func FromAtomic(n *big.Int, decimals int) string {
+	s := new(big.Rat).SetFrac(n, pow10(decimals)).FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits reports whether s consists only of ASCII digits. The empty
+// string is accepted so that "5." and ".5" parse.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
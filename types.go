@@ -1,5 +1,10 @@
 package billingio
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Chain represents a supported blockchain network.
 type Chain string
 
@@ -38,6 +43,20 @@ const (
 	EventTypeCheckoutCompleted       EventType = "checkout.completed"
 	EventTypeCheckoutExpired         EventType = "checkout.expired"
 	EventTypeCheckoutFailed          EventType = "checkout.failed"
+
+	EventTypeSubscriptionCreated   EventType = "subscription.created"
+	EventTypeSubscriptionUpdated   EventType = "subscription.updated"
+	EventTypeSubscriptionPaused    EventType = "subscription.paused"
+	EventTypeSubscriptionCancelled EventType = "subscription.cancelled"
+	EventTypeSubscriptionExpired   EventType = "subscription.expired"
+
+	EventTypeRenewalPaid   EventType = "renewal.paid"
+	EventTypeRenewalFailed EventType = "renewal.failed"
+
+	EventTypePayoutCompleted EventType = "payout.completed"
+	EventTypePayoutFailed    EventType = "payout.failed"
+
+	EventTypeSettlementCreated EventType = "settlement.created"
 )
 
 // WebhookEndpointStatus represents the status of a webhook endpoint.
@@ -54,16 +73,16 @@ type Checkout struct {
 	DepositAddress        string            `json:"deposit_address"`
 	Chain                 Chain             `json:"chain"`
 	Token                 Token             `json:"token"`
-	AmountUSD             float64           `json:"amount_usd"`
+	AmountUSD             Money             `json:"amount_usd"`
 	AmountAtomic          string            `json:"amount_atomic"`
 	Status                CheckoutStatus    `json:"status"`
 	TxHash                *string           `json:"tx_hash"`
 	Confirmations         int               `json:"confirmations"`
 	RequiredConfirmations int               `json:"required_confirmations"`
-	ExpiresAt             string            `json:"expires_at"`
-	DetectedAt            *string           `json:"detected_at"`
-	ConfirmedAt           *string           `json:"confirmed_at"`
-	CreatedAt             string            `json:"created_at"`
+	ExpiresAt             time.Time         `json:"expires_at"`
+	DetectedAt            *time.Time        `json:"detected_at"`
+	ConfirmedAt           *time.Time        `json:"confirmed_at"`
+	CreatedAt             time.Time         `json:"created_at"`
 	Metadata              map[string]string `json:"metadata,omitempty"`
 }
 
@@ -74,8 +93,8 @@ type CheckoutStatusResponse struct {
 	TxHash                *string        `json:"tx_hash"`
 	Confirmations         int            `json:"confirmations"`
 	RequiredConfirmations int            `json:"required_confirmations"`
-	DetectedAt            *string        `json:"detected_at"`
-	ConfirmedAt           *string        `json:"confirmed_at"`
+	DetectedAt            *time.Time     `json:"detected_at"`
+	ConfirmedAt           *time.Time     `json:"confirmed_at"`
 	PollingIntervalMs     int            `json:"polling_interval_ms"`
 }
 
@@ -94,7 +113,11 @@ type WebhookEndpoint struct {
 	Secret      string                `json:"secret,omitempty"`
 	Description *string               `json:"description"`
 	Status      WebhookEndpointStatus `json:"status"`
-	CreatedAt   string                `json:"created_at"`
+	CreatedAt   time.Time             `json:"created_at"`
+
+	// PreviousSecretExpiresAt is set after RollSecret while deliveries are
+	// still also signed with the previous secret.
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
 }
 
 // WebhookEndpointList is a paginated list of webhook endpoints.
@@ -105,12 +128,15 @@ type WebhookEndpointList struct {
 }
 
 // Event represents a webhook event.
+//
+// Data holds the object the event is about; decode it with the accessor
+// matching Type's resource (AsCheckout, AsSubscription, ...).
 type Event struct {
-	EventID    string    `json:"event_id"`
-	Type       EventType `json:"type"`
-	CheckoutID string    `json:"checkout_id"`
-	Data       Checkout  `json:"data"`
-	CreatedAt  string    `json:"created_at"`
+	EventID    string          `json:"event_id"`
+	Type       EventType       `json:"type"`
+	CheckoutID string          `json:"checkout_id,omitempty"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
 }
 
 // EventList is a paginated list of events.
@@ -128,17 +154,20 @@ type HealthResponse struct {
 
 // WebhookEvent is the parsed payload of an incoming webhook delivery.
 // It is returned by VerifyWebhookSignature after successful verification.
+//
+// Data holds the object the event is about; decode it with the accessor
+// matching Type's resource (AsCheckout, AsSubscription, ...).
 type WebhookEvent struct {
-	EventID    string    `json:"event_id"`
-	Type       EventType `json:"type"`
-	CheckoutID string    `json:"checkout_id"`
-	Data       Checkout  `json:"data"`
-	CreatedAt  string    `json:"created_at"`
+	EventID    string          `json:"event_id"`
+	Type       EventType       `json:"type"`
+	CheckoutID string          `json:"checkout_id,omitempty"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
 }
 
 // CreateCheckoutParams are the parameters for creating a checkout.
 type CreateCheckoutParams struct {
-	AmountUSD        float64           `json:"amount_usd"`
+	AmountUSD        Money             `json:"amount_usd"`
 	Chain            Chain             `json:"chain"`
 	Token            Token             `json:"token"`
 	ExpiresInSeconds *int              `json:"expires_in_seconds,omitempty"`
@@ -160,6 +189,123 @@ type CreateWebhookParams struct {
 	URL         string      `json:"url"`
 	Events      []EventType `json:"events"`
 	Description *string     `json:"description,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
+}
+
+// WebhookDeliveryStatus represents the outcome of a webhook delivery attempt.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// UpdateWebhookParams are the parameters for updating a webhook endpoint.
+// Only non-nil fields are changed.
+type UpdateWebhookParams struct {
+	URL         *string                `json:"url,omitempty"`
+	Events      []EventType            `json:"events,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Status      *WebhookEndpointStatus `json:"status,omitempty"`
+}
+
+// SendTestWebhookEventParams are the parameters for sending a test event to
+// a webhook endpoint.
+type SendTestWebhookEventParams struct {
+	// Type is the event type to simulate.
+	Type EventType `json:"type"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
+}
+
+// WebhookDelivery is a single attempt to deliver an event to a webhook
+// endpoint.
+type WebhookDelivery struct {
+	DeliveryID string    `json:"delivery_id"`
+	WebhookID  string    `json:"webhook_id"`
+	EventID    string    `json:"event_id"`
+	EventType  EventType `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+
+	// StatusCode is the HTTP status returned by the endpoint, or nil if no
+	// response was received (e.g. a timeout or connection error).
+	StatusCode *int `json:"status_code"`
+
+	// LatencyMs is the time the endpoint took to respond.
+	LatencyMs int `json:"latency_ms"`
+
+	// Error describes why the attempt failed without a response.
+	Error *string `json:"error"`
+
+	// Status is the outcome of the attempt.
+	Status WebhookDeliveryStatus `json:"status"`
+
+	// ResponseSnippet is the beginning of the endpoint's response body.
+	ResponseSnippet *string `json:"response_snippet"`
+
+	// NextRetryAt is when billing.io will next attempt delivery of the
+	// event, or nil if it will not be retried.
+	NextRetryAt *time.Time `json:"next_retry_at"`
+
+	// Test is true for deliveries triggered by SendTestEvent.
+	Test bool `json:"test"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDeliveryList is a paginated list of webhook deliveries.
+type WebhookDeliveryList struct {
+	Data       []WebhookDelivery `json:"data"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor *string           `json:"next_cursor"`
+}
+
+// ListWebhookDeliveriesParams are the parameters for listing a webhook
+// endpoint's delivery attempts.
+type ListWebhookDeliveriesParams struct {
+	Cursor        *string                `json:"cursor,omitempty"`
+	Limit         *int                   `json:"limit,omitempty"`
+	EventID       *string                `json:"event_id,omitempty"`
+	EventType     *EventType             `json:"event_type,omitempty"`
+	Status        *WebhookDeliveryStatus `json:"status,omitempty"`
+	CreatedAfter  *time.Time             `json:"created_after,omitempty"`
+	CreatedBefore *time.Time             `json:"created_before,omitempty"`
+}
+
+// RedeliverWebhooksSinceParams are the parameters for redelivering an
+// endpoint's undelivered events.
+type RedeliverWebhooksSinceParams struct {
+	// Since is the start of the window, e.g. when the endpoint went down.
+	// Required; must not be in the future.
+	Since time.Time `json:"since"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
+}
+
+// WebhookRedelivery reports the events queued by RedeliverSince.
+type WebhookRedelivery struct {
+	WebhookID string    `json:"webhook_id"`
+	Since     time.Time `json:"since"`
+
+	// Count is the number of events queued for redelivery.
+	Count int `json:"count"`
+}
+
+// RollWebhookSecretParams are the parameters for rolling a webhook endpoint's
+// signing secret.
+type RollWebhookSecretParams struct {
+	// ExpiresInSeconds is how long the previous secret remains valid.
+	// During this overlap deliveries carry a signature for each secret.
+	// 0 revokes the previous secret immediately; nil uses the API default.
+	ExpiresInSeconds *int `json:"expires_in_seconds,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // ListParams are generic pagination parameters for list endpoints.
@@ -195,8 +341,8 @@ type Customer struct {
 	Name       *string           `json:"name"`
 	Status     CustomerStatus    `json:"status"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
-	CreatedAt  string            `json:"created_at"`
-	UpdatedAt  string            `json:"updated_at"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
 }
 
 // CustomerList is a paginated list of customers.
@@ -211,6 +357,9 @@ type CreateCustomerParams struct {
 	Email    string            `json:"email"`
 	Name     *string           `json:"name,omitempty"`
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdateCustomerParams are the parameters for updating a customer.
@@ -256,8 +405,8 @@ type PaymentMethod struct {
 	WalletAddress   string              `json:"wallet_address"`
 	IsDefault       bool                `json:"is_default"`
 	Status          PaymentMethodStatus `json:"status"`
-	CreatedAt       string              `json:"created_at"`
-	UpdatedAt       string              `json:"updated_at"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
 }
 
 // PaymentMethodList is a paginated list of payment methods.
@@ -273,6 +422,9 @@ type CreatePaymentMethodParams struct {
 	Type          PaymentMethodType `json:"type"`
 	Chain         Chain             `json:"chain"`
 	WalletAddress string            `json:"wallet_address"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdatePaymentMethodParams are the parameters for updating a payment method.
@@ -303,13 +455,13 @@ const (
 type PaymentLink struct {
 	PaymentLinkID string            `json:"payment_link_id"`
 	URL           string            `json:"url"`
-	AmountUSD     *float64          `json:"amount_usd"`
+	AmountUSD     *Money            `json:"amount_usd"`
 	Chain         *Chain            `json:"chain"`
 	Token         *Token            `json:"token"`
 	Description   *string           `json:"description"`
 	Status        PaymentLinkStatus `json:"status"`
 	Metadata      map[string]string `json:"metadata,omitempty"`
-	CreatedAt     string            `json:"created_at"`
+	CreatedAt     time.Time         `json:"created_at"`
 }
 
 // PaymentLinkList is a paginated list of payment links.
@@ -321,11 +473,14 @@ type PaymentLinkList struct {
 
 // CreatePaymentLinkParams are the parameters for creating a payment link.
 type CreatePaymentLinkParams struct {
-	AmountUSD   *float64          `json:"amount_usd,omitempty"`
+	AmountUSD   *Money            `json:"amount_usd,omitempty"`
 	Chain       *Chain            `json:"chain,omitempty"`
 	Token       *Token            `json:"token,omitempty"`
 	Description *string           `json:"description,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // ListPaymentLinksParams are the parameters for listing payment links.
@@ -360,12 +515,12 @@ type SubscriptionPlan struct {
 	PlanID          string                 `json:"plan_id"`
 	Name            string                 `json:"name"`
 	Description     *string                `json:"description"`
-	AmountUSD       float64                `json:"amount_usd"`
+	AmountUSD       Money                  `json:"amount_usd"`
 	BillingInterval BillingInterval        `json:"billing_interval"`
 	Status          SubscriptionPlanStatus `json:"status"`
 	Metadata        map[string]string      `json:"metadata,omitempty"`
-	CreatedAt       string                 `json:"created_at"`
-	UpdatedAt       string                 `json:"updated_at"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
 }
 
 // SubscriptionPlanList is a paginated list of subscription plans.
@@ -379,9 +534,12 @@ type SubscriptionPlanList struct {
 type CreateSubscriptionPlanParams struct {
 	Name            string            `json:"name"`
 	Description     *string           `json:"description,omitempty"`
-	AmountUSD       float64           `json:"amount_usd"`
+	AmountUSD       Money             `json:"amount_usd"`
 	BillingInterval BillingInterval   `json:"billing_interval"`
 	Metadata        map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdateSubscriptionPlanParams are the parameters for updating a subscription plan.
@@ -415,16 +573,16 @@ const (
 
 // Subscription represents a customer subscription.
 type Subscription struct {
-	SubscriptionID  string             `json:"subscription_id"`
-	CustomerID      string             `json:"customer_id"`
-	PlanID          string             `json:"plan_id"`
-	Status          SubscriptionStatus `json:"status"`
-	CurrentPeriodStart string          `json:"current_period_start"`
-	CurrentPeriodEnd   string          `json:"current_period_end"`
-	CancelledAt     *string            `json:"cancelled_at"`
-	Metadata        map[string]string  `json:"metadata,omitempty"`
-	CreatedAt       string             `json:"created_at"`
-	UpdatedAt       string             `json:"updated_at"`
+	SubscriptionID     string             `json:"subscription_id"`
+	CustomerID         string             `json:"customer_id"`
+	PlanID             string             `json:"plan_id"`
+	Status             SubscriptionStatus `json:"status"`
+	CurrentPeriodStart time.Time          `json:"current_period_start"`
+	CurrentPeriodEnd   time.Time          `json:"current_period_end"`
+	CancelledAt        *time.Time         `json:"cancelled_at"`
+	Metadata           map[string]string  `json:"metadata,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
 }
 
 // SubscriptionList is a paginated list of subscriptions.
@@ -439,6 +597,9 @@ type CreateSubscriptionParams struct {
 	CustomerID string            `json:"customer_id"`
 	PlanID     string            `json:"plan_id"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdateSubscriptionParams are the parameters for updating a subscription.
@@ -464,10 +625,10 @@ type ListSubscriptionsParams struct {
 type RenewalStatus string
 
 const (
-	RenewalStatusPending   RenewalStatus = "pending"
-	RenewalStatusPaid      RenewalStatus = "paid"
-	RenewalStatusFailed    RenewalStatus = "failed"
-	RenewalStatusRetrying  RenewalStatus = "retrying"
+	RenewalStatusPending  RenewalStatus = "pending"
+	RenewalStatusPaid     RenewalStatus = "paid"
+	RenewalStatusFailed   RenewalStatus = "failed"
+	RenewalStatusRetrying RenewalStatus = "retrying"
 )
 
 // SubscriptionRenewal represents a single billing-cycle renewal.
@@ -475,13 +636,13 @@ type SubscriptionRenewal struct {
 	RenewalID      string        `json:"renewal_id"`
 	SubscriptionID string        `json:"subscription_id"`
 	PlanID         string        `json:"plan_id"`
-	AmountUSD      float64       `json:"amount_usd"`
+	AmountUSD      Money         `json:"amount_usd"`
 	Status         RenewalStatus `json:"status"`
-	PeriodStart    string        `json:"period_start"`
-	PeriodEnd      string        `json:"period_end"`
-	PaidAt         *string       `json:"paid_at"`
-	FailedAt       *string       `json:"failed_at"`
-	CreatedAt      string        `json:"created_at"`
+	PeriodStart    time.Time     `json:"period_start"`
+	PeriodEnd      time.Time     `json:"period_end"`
+	PaidAt         *time.Time    `json:"paid_at"`
+	FailedAt       *time.Time    `json:"failed_at"`
+	CreatedAt      time.Time     `json:"created_at"`
 }
 
 // SubscriptionRenewalList is a paginated list of subscription renewals.
@@ -510,8 +671,8 @@ type Entitlement struct {
 	FeatureKey     string            `json:"feature_key"`
 	Value          string            `json:"value"`
 	Metadata       map[string]string `json:"metadata,omitempty"`
-	CreatedAt      string            `json:"created_at"`
-	UpdatedAt      string            `json:"updated_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 // EntitlementList is a paginated list of entitlements.
@@ -533,6 +694,9 @@ type CreateEntitlementParams struct {
 	FeatureKey     string            `json:"feature_key"`
 	Value          string            `json:"value"`
 	Metadata       map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdateEntitlementParams are the parameters for updating an entitlement.
@@ -572,16 +736,16 @@ const (
 // Payout represents a payout intent.
 type Payout struct {
 	PayoutID      string            `json:"payout_id"`
-	AmountUSD     float64           `json:"amount_usd"`
+	AmountUSD     Money             `json:"amount_usd"`
 	Chain         Chain             `json:"chain"`
 	Token         Token             `json:"token"`
 	WalletAddress string            `json:"wallet_address"`
 	TxHash        *string           `json:"tx_hash"`
 	Status        PayoutStatus      `json:"status"`
 	Metadata      map[string]string `json:"metadata,omitempty"`
-	ExecutedAt    *string           `json:"executed_at"`
-	CreatedAt     string            `json:"created_at"`
-	UpdatedAt     string            `json:"updated_at"`
+	ExecutedAt    *time.Time        `json:"executed_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
 }
 
 // PayoutList is a paginated list of payouts.
@@ -593,11 +757,14 @@ type PayoutList struct {
 
 // CreatePayoutParams are the parameters for creating a payout.
 type CreatePayoutParams struct {
-	AmountUSD     float64           `json:"amount_usd"`
+	AmountUSD     Money             `json:"amount_usd"`
 	Chain         Chain             `json:"chain"`
 	Token         Token             `json:"token"`
 	WalletAddress string            `json:"wallet_address"`
 	Metadata      map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // UpdatePayoutParams are the parameters for updating a payout.
@@ -618,14 +785,14 @@ type ListPayoutsParams struct {
 
 // Settlement represents a completed settlement record.
 type Settlement struct {
-	SettlementID string  `json:"settlement_id"`
-	PayoutID     string  `json:"payout_id"`
-	AmountUSD    float64 `json:"amount_usd"`
-	Chain        Chain   `json:"chain"`
-	Token        Token   `json:"token"`
-	TxHash       string  `json:"tx_hash"`
-	SettledAt    string  `json:"settled_at"`
-	CreatedAt    string  `json:"created_at"`
+	SettlementID string    `json:"settlement_id"`
+	PayoutID     string    `json:"payout_id"`
+	AmountUSD    Money     `json:"amount_usd"`
+	Chain        Chain     `json:"chain"`
+	Token        Token     `json:"token"`
+	TxHash       string    `json:"tx_hash"`
+	SettledAt    time.Time `json:"settled_at"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 // SettlementList is a paginated list of settlements.
@@ -659,12 +826,12 @@ const (
 type RevenueEvent struct {
 	RevenueEventID string           `json:"revenue_event_id"`
 	Type           RevenueEventType `json:"type"`
-	AmountUSD      float64          `json:"amount_usd"`
+	AmountUSD      Money            `json:"amount_usd"`
 	CustomerID     *string          `json:"customer_id"`
 	CheckoutID     *string          `json:"checkout_id"`
 	SubscriptionID *string          `json:"subscription_id"`
 	Description    *string          `json:"description"`
-	CreatedAt      string           `json:"created_at"`
+	CreatedAt      time.Time        `json:"created_at"`
 }
 
 // RevenueEventList is a paginated list of revenue events.
@@ -683,19 +850,19 @@ type ListRevenueEventsParams struct {
 
 // AccountingSummary is the response from the revenue accounting endpoint.
 type AccountingSummary struct {
-	TotalRevenueUSD  float64 `json:"total_revenue_usd"`
-	TotalRefundsUSD  float64 `json:"total_refunds_usd"`
-	NetRevenueUSD    float64 `json:"net_revenue_usd"`
-	TotalCharges     int     `json:"total_charges"`
-	TotalRefunds     int     `json:"total_refunds"`
-	PeriodStart      string  `json:"period_start"`
-	PeriodEnd        string  `json:"period_end"`
+	TotalRevenueUSD Money     `json:"total_revenue_usd"`
+	TotalRefundsUSD Money     `json:"total_refunds_usd"`
+	NetRevenueUSD   Money     `json:"net_revenue_usd"`
+	TotalCharges    int       `json:"total_charges"`
+	TotalRefunds    int       `json:"total_refunds"`
+	PeriodStart     time.Time `json:"period_start"`
+	PeriodEnd       time.Time `json:"period_end"`
 }
 
 // AccountingSummaryParams are the parameters for querying the accounting summary.
 type AccountingSummaryParams struct {
-	PeriodStart *string `json:"period_start,omitempty"`
-	PeriodEnd   *string `json:"period_end,omitempty"`
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
@@ -714,11 +881,11 @@ const (
 type Adjustment struct {
 	AdjustmentID string            `json:"adjustment_id"`
 	Type         AdjustmentType    `json:"type"`
-	AmountUSD    float64           `json:"amount_usd"`
+	AmountUSD    Money             `json:"amount_usd"`
 	CustomerID   *string           `json:"customer_id"`
 	Description  *string           `json:"description"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
-	CreatedAt    string            `json:"created_at"`
+	CreatedAt    time.Time         `json:"created_at"`
 }
 
 // AdjustmentList is a paginated list of adjustments.
@@ -731,10 +898,13 @@ type AdjustmentList struct {
 // CreateAdjustmentParams are the parameters for creating an adjustment.
 type CreateAdjustmentParams struct {
 	Type        AdjustmentType    `json:"type"`
-	AmountUSD   float64           `json:"amount_usd"`
+	AmountUSD   Money             `json:"amount_usd"`
 	CustomerID  *string           `json:"customer_id,omitempty"`
 	Description *string           `json:"description,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Optional.
+	IdempotencyKey string `json:"-"`
 }
 
 // ListAdjustmentsParams are the parameters for listing adjustments.
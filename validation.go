@@ -0,0 +1,578 @@
+package billingio
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/billing-io/billing-go/address"
+)
+
+// maxListLimit is the largest page size accepted by list endpoints.
+const maxListLimit = 100
+
+// ValidationError is returned when params fail client-side validation. No
+// request is sent in that case.
+type ValidationError struct {
+	// Fields lists every invalid parameter.
+	Fields []FieldError
+}
+
+// FieldError describes a single invalid parameter.
+type FieldError struct {
+	// Param is the parameter name as used by the API and Error.Param
+	// (e.g. "amount_usd", "events[1]").
+	Param string
+
+	// Message explains what is wrong with the value.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Param + " " + f.Message
+	}
+	return "billingio: invalid params: " + strings.Join(parts, "; ")
+}
+
+// WithoutValidation disables the client-side Validate call made before each
+// request. Strict enum checks enabled with WithStrictValidation still apply.
+func WithoutValidation() Option {
+	return func(c *Client) {
+		c.skipValidation = true
+	}
+}
+
+// validator collects field errors.
+type validator struct {
+	fields []FieldError
+}
+
+// addf records an error for param.
+func (v *validator) addf(param, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Param: param, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns a *ValidationError if any field errors were recorded.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+// required records an error if s is empty.
+func (v *validator) required(param, s string) {
+	if strings.TrimSpace(s) == "" {
+		v.addf(param, "is required")
+	}
+}
+
+// positive records an error if m is not greater than zero.
+func (v *validator) positive(param string, m Money) {
+	if m.Cmp(Money{}) <= 0 {
+		v.addf(param, "must be greater than 0")
+	}
+}
+
+// limit records an error if a page size is out of range.
+func (v *validator) limit(l *int) {
+	if l != nil && (*l < 1 || *l > maxListLimit) {
+		v.addf("limit", "must be between 1 and %d", maxListLimit)
+	}
+}
+
+// email records an error if s is set but not a valid email address.
+func (v *validator) email(param, s string) {
+	if _, err := mail.ParseAddress(s); err != nil {
+		v.addf(param, "must be a valid email address")
+	}
+}
+
+// webhookURL records an error if s is not an absolute https URL.
+func (v *validator) webhookURL(param, s string) {
+	if u, err := url.Parse(s); err != nil || u.Scheme != "https" || u.Host == "" {
+		v.addf(param, "must be an absolute https URL")
+	}
+}
+
+// eventTypes records an error for each empty entry in events.
+func (v *validator) eventTypes(param string, events []EventType) {
+	for i, e := range events {
+		if e == "" {
+			v.addf(fmt.Sprintf("%s[%d]", param, i), "is required")
+		}
+	}
+}
+
+// walletAddress records an error if addr is not a valid address on chain.
+// Addresses on chains unknown to this SDK version are left to the API.
+func (v *validator) walletAddress(param string, chain Chain, addr string) {
+	if strings.TrimSpace(addr) == "" || !chain.IsKnown() {
+		return
+	}
+	if err := address.Validate(string(chain), addr); err != nil {
+		if errors.Is(err, address.ErrChecksum) {
+			v.addf(param, "has an invalid checksum; check for a typo")
+		} else {
+			v.addf(param, "is not a valid %s address", chain)
+		}
+	}
+}
+
+// normalizeAddress returns addr in its canonical form on chain, or addr
+// unchanged if it cannot be normalized.
+func normalizeAddress(chain Chain, addr string) string {
+	if n, err := address.Normalize(string(chain), addr); err == nil {
+		return n
+	}
+	return addr
+}
+
+// paramsValidator is implemented by every params struct.
+type paramsValidator interface {
+	validate(v *validator)
+}
+
+// validate checks params before a request is sent. It runs the params'
+// Validate rules unless disabled with WithoutValidation, plus the strict
+// enum checks when enabled with WithStrictValidation.
+func (c *Client) validate(params any) error {
+	v := &validator{}
+	if pv, ok := params.(paramsValidator); ok && !c.skipValidation {
+		pv.validate(v)
+	}
+	if ec, ok := params.(enumChecker); ok && c.strict {
+		ec.checkEnums(v)
+	}
+	return v.err()
+}
+
+// ---------------------------------------------------------------------------
+// Checkouts, webhooks and events
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreateCheckoutParams) Validate() error { return validateParams(p) }
+
+func (p *CreateCheckoutParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.positive("amount_usd", p.AmountUSD)
+	v.required("chain", string(p.Chain))
+	v.required("token", string(p.Token))
+	if p.ExpiresInSeconds != nil && *p.ExpiresInSeconds <= 0 {
+		v.addf("expires_in_seconds", "must be greater than 0")
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListCheckoutsParams) Validate() error { return validateParams(p) }
+
+func (p *ListCheckoutsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *CreateWebhookParams) Validate() error { return validateParams(p) }
+
+func (p *CreateWebhookParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	if p.URL == "" {
+		v.addf("url", "is required")
+	} else {
+		v.webhookURL("url", p.URL)
+	}
+	if len(p.Events) == 0 {
+		v.addf("events", "must contain at least one event type")
+	}
+	v.eventTypes("events", p.Events)
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdateWebhookParams) Validate() error { return validateParams(p) }
+
+func (p *UpdateWebhookParams) validate(v *validator) {
+	if p == nil {
+		return
+	}
+	if p.URL != nil {
+		v.webhookURL("url", *p.URL)
+	}
+	v.eventTypes("events", p.Events)
+}
+
+// Validate checks the params without contacting the API.
+func (p *SendTestWebhookEventParams) Validate() error { return validateParams(p) }
+
+func (p *SendTestWebhookEventParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("type", string(p.Type))
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListWebhookDeliveriesParams) Validate() error { return validateParams(p) }
+
+func (p *ListWebhookDeliveriesParams) validate(v *validator) {
+	if p == nil {
+		return
+	}
+	v.limit(p.Limit)
+	if p.CreatedAfter != nil && p.CreatedBefore != nil && !p.CreatedBefore.After(*p.CreatedAfter) {
+		v.addf("created_before", "must be after created_after")
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *RedeliverWebhooksSinceParams) Validate() error { return validateParams(p) }
+
+func (p *RedeliverWebhooksSinceParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	switch {
+	case p.Since.IsZero():
+		v.addf("since", "is required")
+	case p.Since.After(time.Now()):
+		v.addf("since", "must not be in the future")
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *RollWebhookSecretParams) Validate() error { return validateParams(p) }
+
+func (p *RollWebhookSecretParams) validate(v *validator) {
+	if p != nil && p.ExpiresInSeconds != nil && *p.ExpiresInSeconds < 0 {
+		v.addf("expires_in_seconds", "must not be negative")
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListParams) Validate() error { return validateParams(p) }
+
+func (p *ListParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListEventsParams) Validate() error { return validateParams(p) }
+
+func (p *ListEventsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Customers
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreateCustomerParams) Validate() error { return validateParams(p) }
+
+func (p *CreateCustomerParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	if p.Email == "" {
+		v.addf("email", "is required")
+	} else {
+		v.email("email", p.Email)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdateCustomerParams) Validate() error { return validateParams(p) }
+
+func (p *UpdateCustomerParams) validate(v *validator) {
+	if p != nil && p.Email != nil {
+		v.email("email", *p.Email)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListCustomersParams) Validate() error { return validateParams(p) }
+
+func (p *ListCustomersParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Payment methods and links
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreatePaymentMethodParams) Validate() error { return validateParams(p) }
+
+func (p *CreatePaymentMethodParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("customer_id", p.CustomerID)
+	v.required("type", string(p.Type))
+	v.required("chain", string(p.Chain))
+	v.required("wallet_address", p.WalletAddress)
+	v.walletAddress("wallet_address", p.Chain, p.WalletAddress)
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdatePaymentMethodParams) Validate() error { return validateParams(p) }
+
+func (p *UpdatePaymentMethodParams) validate(v *validator) {}
+
+// Validate checks the params without contacting the API.
+func (p *ListPaymentMethodsParams) Validate() error { return validateParams(p) }
+
+func (p *ListPaymentMethodsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *CreatePaymentLinkParams) Validate() error { return validateParams(p) }
+
+func (p *CreatePaymentLinkParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	if p.AmountUSD != nil {
+		v.positive("amount_usd", *p.AmountUSD)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListPaymentLinksParams) Validate() error { return validateParams(p) }
+
+func (p *ListPaymentLinksParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Subscriptions
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreateSubscriptionPlanParams) Validate() error { return validateParams(p) }
+
+func (p *CreateSubscriptionPlanParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("name", p.Name)
+	v.positive("amount_usd", p.AmountUSD)
+	v.required("billing_interval", string(p.BillingInterval))
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdateSubscriptionPlanParams) Validate() error { return validateParams(p) }
+
+func (p *UpdateSubscriptionPlanParams) validate(v *validator) {
+	if p != nil && p.Name != nil {
+		v.required("name", *p.Name)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListSubscriptionPlansParams) Validate() error { return validateParams(p) }
+
+func (p *ListSubscriptionPlansParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *CreateSubscriptionParams) Validate() error { return validateParams(p) }
+
+func (p *CreateSubscriptionParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("customer_id", p.CustomerID)
+	v.required("plan_id", p.PlanID)
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdateSubscriptionParams) Validate() error { return validateParams(p) }
+
+func (p *UpdateSubscriptionParams) validate(v *validator) {}
+
+// Validate checks the params without contacting the API.
+func (p *ListSubscriptionsParams) Validate() error { return validateParams(p) }
+
+func (p *ListSubscriptionsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListSubscriptionRenewalsParams) Validate() error { return validateParams(p) }
+
+func (p *ListSubscriptionRenewalsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Entitlements
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreateEntitlementParams) Validate() error { return validateParams(p) }
+
+func (p *CreateEntitlementParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("subscription_id", p.SubscriptionID)
+	v.required("feature_key", p.FeatureKey)
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdateEntitlementParams) Validate() error { return validateParams(p) }
+
+func (p *UpdateEntitlementParams) validate(v *validator) {}
+
+// Validate checks the params without contacting the API.
+func (p *ListEntitlementsParams) Validate() error { return validateParams(p) }
+
+func (p *ListEntitlementsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *CheckEntitlementParams) Validate() error { return validateParams(p) }
+
+func (p *CheckEntitlementParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("customer_id", p.CustomerID)
+	v.required("feature_key", p.FeatureKey)
+}
+
+// ---------------------------------------------------------------------------
+// Payouts and settlements
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *CreatePayoutParams) Validate() error { return validateParams(p) }
+
+func (p *CreatePayoutParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.positive("amount_usd", p.AmountUSD)
+	v.required("chain", string(p.Chain))
+	v.required("token", string(p.Token))
+	v.required("wallet_address", p.WalletAddress)
+	v.walletAddress("wallet_address", p.Chain, p.WalletAddress)
+}
+
+// Validate checks the params without contacting the API.
+func (p *UpdatePayoutParams) Validate() error { return validateParams(p) }
+
+func (p *UpdatePayoutParams) validate(v *validator) {}
+
+// Validate checks the params without contacting the API.
+func (p *ListPayoutsParams) Validate() error { return validateParams(p) }
+
+func (p *ListPayoutsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListSettlementsParams) Validate() error { return validateParams(p) }
+
+func (p *ListSettlementsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Revenue events and adjustments
+// ---------------------------------------------------------------------------
+
+// Validate checks the params without contacting the API.
+func (p *ListRevenueEventsParams) Validate() error { return validateParams(p) }
+
+func (p *ListRevenueEventsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *AccountingSummaryParams) Validate() error { return validateParams(p) }
+
+func (p *AccountingSummaryParams) validate(v *validator) {
+	if p != nil && p.PeriodStart != nil && p.PeriodEnd != nil && !p.PeriodEnd.After(*p.PeriodStart) {
+		v.addf("period_end", "must be after period_start")
+	}
+}
+
+// Validate checks the params without contacting the API.
+func (p *CreateAdjustmentParams) Validate() error { return validateParams(p) }
+
+func (p *CreateAdjustmentParams) validate(v *validator) {
+	if p == nil {
+		v.addf("params", "are required")
+		return
+	}
+	v.required("type", string(p.Type))
+	v.positive("amount_usd", p.AmountUSD)
+}
+
+// Validate checks the params without contacting the API.
+func (p *ListAdjustmentsParams) Validate() error { return validateParams(p) }
+
+func (p *ListAdjustmentsParams) validate(v *validator) {
+	if p != nil {
+		v.limit(p.Limit)
+	}
+}
+
+// validateParams runs p's validation rules.
+func validateParams(p paramsValidator) error {
+	v := &validator{}
+	p.validate(v)
+	return v.err()
+}
@@ -0,0 +1,24 @@
+package billingio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedeliverWebhooksSinceParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *RedeliverWebhooksSinceParams
+		ok     bool
+	}{
+		{"past", &RedeliverWebhooksSinceParams{Since: time.Now().Add(-time.Hour)}, true},
+		{"nil", nil, false},
+		{"zero", &RedeliverWebhooksSinceParams{}, false},
+		{"future", &RedeliverWebhooksSinceParams{Since: time.Now().Add(time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.params.Validate(); tt.ok != (err == nil) {
+			t.Errorf("%s: Validate() = %v, want ok = %v", tt.name, err, tt.ok)
+		}
+	}
+}
@@ -12,9 +12,20 @@ type WebhookService struct {
 
 // Create registers a new webhook endpoint.
 // The returned WebhookEndpoint includes the signing secret -- store it securely.
-func (s *WebhookService) Create(ctx context.Context, params *CreateWebhookParams) (*WebhookEndpoint, error) {
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *WebhookService) Create(ctx context.Context, params *CreateWebhookParams, opts ...RequestOption) (*WebhookEndpoint, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
 	var endpoint WebhookEndpoint
-	err := s.client.post(ctx, "/webhooks", params, &endpoint, nil)
+	err := s.client.post(ctx, "Webhooks.Create", "/webhooks", params, &endpoint, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +33,11 @@ func (s *WebhookService) Create(ctx context.Context, params *CreateWebhookParams
 }
 
 // List returns a paginated list of webhook endpoints.
-func (s *WebhookService) List(ctx context.Context, params *ListParams) (*WebhookEndpointList, error) {
+func (s *WebhookService) List(ctx context.Context, params *ListParams, opts ...RequestOption) (*WebhookEndpointList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	qp := make(map[string]string)
 	if params != nil {
 		qp["cursor"] = strOrEmpty(params.Cursor)
@@ -31,7 +46,7 @@ func (s *WebhookService) List(ctx context.Context, params *ListParams) (*Webhook
 	path := addQueryParams("/webhooks", qp)
 
 	var list WebhookEndpointList
-	err := s.client.get(ctx, path, &list)
+	err := s.client.get(ctx, "Webhooks.List", path, &list, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,31 +54,178 @@ func (s *WebhookService) List(ctx context.Context, params *ListParams) (*Webhook
 }
 
 // Get retrieves a single webhook endpoint by ID.
-func (s *WebhookService) Get(ctx context.Context, webhookID string) (*WebhookEndpoint, error) {
+func (s *WebhookService) Get(ctx context.Context, webhookID string, opts ...RequestOption) (*WebhookEndpoint, error) {
+	var endpoint WebhookEndpoint
+	err := s.client.get(ctx, "Webhooks.Get", fmt.Sprintf("/webhooks/%s", webhookID), &endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// Update changes a webhook endpoint's URL, subscribed events, description
+// or status. Set Status to WebhookEndpointStatusDisabled to pause deliveries
+// and back to WebhookEndpointStatusActive to resume them.
+func (s *WebhookService) Update(ctx context.Context, webhookID string, params *UpdateWebhookParams, opts ...RequestOption) (*WebhookEndpoint, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
 	var endpoint WebhookEndpoint
-	err := s.client.get(ctx, fmt.Sprintf("/webhooks/%s", webhookID), &endpoint)
+	err := s.client.patch(ctx, "Webhooks.Update", fmt.Sprintf("/webhooks/%s", webhookID), params, &endpoint, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &endpoint, nil
 }
 
+// SendTestEvent triggers a synthetic delivery of an event of params.Type to
+// the endpoint and returns the resulting delivery attempt.
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *WebhookService) SendTestEvent(ctx context.Context, webhookID string, params *SendTestWebhookEventParams, opts ...RequestOption) (*WebhookDelivery, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
+	var delivery WebhookDelivery
+	err := s.client.post(ctx, "Webhooks.SendTestEvent", fmt.Sprintf("/webhooks/%s/test", webhookID), params, &delivery, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries returns a paginated list of delivery attempts to the
+// endpoint, newest first, with their response codes, latencies and retry
+// schedule. Use params to filter by event, status or time range.
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID string, params *ListWebhookDeliveriesParams, opts ...RequestOption) (*WebhookDeliveryList, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	qp := make(map[string]string)
+	if params != nil {
+		qp["cursor"] = strOrEmpty(params.Cursor)
+		qp["limit"] = intToString(params.Limit)
+		qp["event_id"] = strOrEmpty(params.EventID)
+		if params.EventType != nil {
+			qp["event_type"] = string(*params.EventType)
+		}
+		if params.Status != nil {
+			qp["status"] = string(*params.Status)
+		}
+		qp["created_after"] = timeToString(params.CreatedAfter)
+		qp["created_before"] = timeToString(params.CreatedBefore)
+	}
+	path := addQueryParams(fmt.Sprintf("/webhooks/%s/deliveries", webhookID), qp)
+
+	var list WebhookDeliveryList
+	err := s.client.get(ctx, "Webhooks.ListDeliveries", path, &list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Redeliver queues a new delivery of the event from an earlier delivery
+// attempt and returns the new attempt. Pass WithIdempotencyKey to choose the
+// Idempotency-Key; otherwise a key is generated for the call.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID string, opts ...RequestOption) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := s.client.post(ctx, "Webhooks.Redeliver", fmt.Sprintf("/webhooks/deliveries/%s/redeliver", deliveryID), nil, &delivery, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// RedeliverSince queues a new delivery of every event sent to the endpoint
+// since the given time that has not been delivered successfully, e.g. after
+// the endpoint was down.
+//
+// If params.IdempotencyKey is set it is sent as the Idempotency-Key header;
+// otherwise a key is generated for the call.
+func (s *WebhookService) RedeliverSince(ctx context.Context, webhookID string, params *RedeliverWebhooksSinceParams, opts ...RequestOption) (*WebhookRedelivery, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		opts = withParamsIdempotencyKey(params.IdempotencyKey, opts)
+	}
+
+	var result WebhookRedelivery
+	err := s.client.post(ctx, "Webhooks.RedeliverSince", fmt.Sprintf("/webhooks/%s/redeliver", webhookID), params, &result, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // Delete removes a webhook endpoint.
-func (s *WebhookService) Delete(ctx context.Context, webhookID string) error {
-	return s.client.del(ctx, fmt.Sprintf("/webhooks/%s", webhookID))
+func (s *WebhookService) Delete(ctx context.Context, webhookID string, opts ...RequestOption) error {
+	return s.client.del(ctx, "Webhooks.Delete", fmt.Sprintf("/webhooks/%s", webhookID), opts...)
+}
+
+// RollSecret replaces the endpoint's signing secret. The returned
+// WebhookEndpoint includes the new secret -- store it securely.
+//
+// Until PreviousSecretExpiresAt, deliveries are signed with both the new and
+// the previous secret, so receivers can switch over without dropping events:
+// verify with both secrets (VerifyWebhookSignatureWithSecrets or
+// webhook.WithSecrets), then retire the old one. params may be nil.
+func (s *WebhookService) RollSecret(ctx context.Context, webhookID string, params *RollWebhookSecretParams, opts ...RequestOption) (*WebhookEndpoint, error) {
+	if err := s.client.validate(params); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &RollWebhookSecretParams{}
+	}
+
+	var endpoint WebhookEndpoint
+	err := s.client.post(ctx, "Webhooks.RollSecret", fmt.Sprintf("/webhooks/%s/roll-secret", webhookID), params, &endpoint, withParamsIdempotencyKey(params.IdempotencyKey, opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
 }
 
 // ListAutoPaginate returns an iterator that automatically fetches subsequent
 // pages of webhook endpoints. See Iter for usage details.
-func (s *WebhookService) ListAutoPaginate(ctx context.Context, params *ListParams) *Iter[WebhookEndpoint] {
+func (s *WebhookService) ListAutoPaginate(ctx context.Context, params *ListParams, opts ...RequestOption) *Iter[WebhookEndpoint] {
 	if params == nil {
 		params = &ListParams{}
 	}
 	p := *params
 
-	return newIter(func(cursor *string) ([]WebhookEndpoint, bool, *string, error) {
+	return newIter(ctx, s.client, "Webhooks", func(ctx context.Context, cursor *string) ([]WebhookEndpoint, bool, *string, error) {
+		p.Cursor = cursor
+		list, err := s.List(ctx, &p, opts...)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		return list.Data, list.HasMore, list.NextCursor, nil
+	})
+}
+
+// ListDeliveriesAutoPaginate returns an iterator that automatically fetches
+// subsequent pages of the endpoint's delivery attempts. See Iter for usage
+// details.
+func (s *WebhookService) ListDeliveriesAutoPaginate(ctx context.Context, webhookID string, params *ListWebhookDeliveriesParams, opts ...RequestOption) *Iter[WebhookDelivery] {
+	if params == nil {
+		params = &ListWebhookDeliveriesParams{}
+	}
+	p := *params
+
+	return newIter(ctx, s.client, "WebhookDeliveries", func(ctx context.Context, cursor *string) ([]WebhookDelivery, bool, *string, error) {
 		p.Cursor = cursor
-		list, err := s.List(ctx, &p)
+		list, err := s.ListDeliveries(ctx, webhookID, &p, opts...)
 		if err != nil {
 			return nil, false, nil, err
 		}
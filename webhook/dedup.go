@@ -0,0 +1,274 @@
+package webhook
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DedupStore records keys for a limited time. A Handler configured with
+// WithDedupStore uses it to reject replayed signatures and to skip events
+// whose EventID was already processed.
+//
+// Implementations must be safe for concurrent use. Use a shared store (e.g.
+// one backed by Redis or a database) when several instances receive
+// deliveries for the same endpoint.
+type DedupStore interface {
+	// Add records key until ttl elapses. It reports false, without
+	// changing the existing entry, if key is already recorded and has not
+	// expired.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Remove forgets key, so that a later Add succeeds. The Handler calls
+	// it when processing an event fails, so the redelivery is handled.
+	Remove(ctx context.Context, key string) error
+}
+
+// DefaultMemoryStoreSize is the default capacity of a MemoryStore.
+const DefaultMemoryStoreSize = 10000
+
+// MemoryStore is an in-memory DedupStore. It holds at most a fixed number of
+// keys and evicts the least recently added when full.
+type MemoryStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // front is most recently added
+}
+
+// memoryEntry is a key held by a MemoryStore.
+type memoryEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewMemoryStore returns a MemoryStore holding up to size keys. A size of 0
+// or less uses DefaultMemoryStoreSize.
+func NewMemoryStore(size int) *MemoryStore {
+	if size <= 0 {
+		size = DefaultMemoryStoreSize
+	}
+	return &MemoryStore{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Add implements DedupStore.
+func (s *MemoryStore) Add(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if el, ok := s.entries[key]; ok {
+		if e := el.Value.(*memoryEntry); now.Before(e.expires) {
+			return false, nil
+		}
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, expires: now.Add(ttl)})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return true, nil
+}
+
+// Remove implements DedupStore.
+func (s *MemoryStore) Remove(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// FileStore is a DedupStore persisted to a local file, so that processed
+// events survive restarts. It is intended for a single process; the file is
+// an append-only log that is compacted when the store is opened and
+// whenever it holds many more lines than live keys.
+type FileStore struct {
+	mu        sync.Mutex
+	path      string
+	f         *os.File
+	entries   map[string]time.Time
+	lines     int // lines in the log
+	compactAt int // line count that triggers the next compaction
+}
+
+// compactSlack is the number of lines the log may hold beyond twice the
+// live keys before it is compacted.
+const compactSlack = 1000
+
+// NewFileStore opens or creates a FileStore at path. Call Close when done.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, entries: make(map[string]time.Time)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add implements DedupStore.
+func (s *FileStore) Add(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if exp, ok := s.entries[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	exp := now.Add(ttl)
+	if err := s.append(key, exp); err != nil {
+		return false, err
+	}
+	s.entries[key] = exp
+	s.maybeCompact(now)
+	return true, nil
+}
+
+// Remove implements DedupStore.
+func (s *FileStore) Remove(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[key]; !ok {
+		return nil
+	}
+	if err := s.append(key, time.Time{}); err != nil {
+		return err
+	}
+	delete(s.entries, key)
+	s.maybeCompact(time.Now())
+	return nil
+}
+
+// Close closes the underlying file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// append writes an entry to the log. A zero exp records a removal.
+func (s *FileStore) append(key string, exp time.Time) error {
+	var ns int64
+	if !exp.IsZero() {
+		ns = exp.UnixNano()
+	}
+	if _, err := fmt.Fprintf(s.f, "%d %s\n", ns, strconv.Quote(key)); err != nil {
+		return fmt.Errorf("webhook: failed to write dedup store: %w", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("webhook: failed to write dedup store: %w", err)
+	}
+	s.lines++
+	return nil
+}
+
+// maybeCompact drops expired entries and rewrites the log once it has grown
+// well beyond the live keys. A failed compaction leaves the current log in
+// use and is retried after further writes.
+func (s *FileStore) maybeCompact(now time.Time) {
+	if s.lines < s.compactAt {
+		return
+	}
+	for k, exp := range s.entries {
+		if !now.Before(exp) {
+			delete(s.entries, k)
+		}
+	}
+	if err := s.compact(); err != nil {
+		s.compactAt = s.lines + compactSlack
+	}
+}
+
+// load replays the log into memory. Malformed lines, such as a final line
+// cut short by a crash, are skipped.
+func (s *FileStore) load() error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("webhook: failed to open dedup store: %w", err)
+	}
+	defer f.Close()
+
+	now := time.Now()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		nsStr, quoted, ok := strings.Cut(sc.Text(), " ")
+		if !ok {
+			continue
+		}
+		ns, err := strconv.ParseInt(nsStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		key, err := strconv.Unquote(quoted)
+		if err != nil {
+			continue
+		}
+		if exp := time.Unix(0, ns); ns != 0 && now.Before(exp) {
+			s.entries[key] = exp
+		} else {
+			delete(s.entries, key)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("webhook: failed to read dedup store: %w", err)
+	}
+	return nil
+}
+
+// compact rewrites the log with only the live entries and appends to the
+// new log from then on. On failure the current log is left in place.
+func (s *FileStore) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("webhook: failed to compact dedup store: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	for key, exp := range s.entries {
+		fmt.Fprintf(w, "%d %s\n", exp.UnixNano(), strconv.Quote(key))
+	}
+	err = w.Flush()
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("webhook: failed to compact dedup store: %w", err)
+	}
+
+	// The new log is positioned at its end, so it is kept open for
+	// appending rather than reopened by path.
+	if s.f != nil {
+		s.f.Close()
+	}
+	s.f = tmp
+	s.lines = len(s.entries)
+	s.compactAt = 2*s.lines + compactSlack
+	return nil
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+
+	if ok, _ := s.Add(ctx, "a", time.Hour); !ok {
+		t.Fatal("first Add(a) = false")
+	}
+	if ok, _ := s.Add(ctx, "a", time.Hour); ok {
+		t.Error("second Add(a) = true")
+	}
+	s.Remove(ctx, "a")
+	if ok, _ := s.Add(ctx, "a", time.Hour); !ok {
+		t.Error("Add(a) after Remove = false")
+	}
+
+	// Capacity 2: adding c evicts a, the least recently added.
+	s.Add(ctx, "b", time.Hour)
+	s.Add(ctx, "c", time.Hour)
+	if ok, _ := s.Add(ctx, "a", time.Hour); !ok {
+		t.Error("Add(a) after eviction = false")
+	}
+
+	// Expired keys can be added again.
+	s.Add(ctx, "d", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if ok, _ := s.Add(ctx, "d", time.Hour); !ok {
+		t.Error("Add(d) after expiry = false")
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dedup.log")
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add(ctx, "kept", time.Hour)
+	s.Add(ctx, "removed", time.Hour)
+	s.Add(ctx, "expired", time.Nanosecond)
+	s.Remove(ctx, "removed")
+	s.Close()
+
+	// Simulate a write cut short by a crash.
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`123 "trunc`)
+	f.Close()
+
+	s, err = NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for key, want := range map[string]bool{"kept": false, "removed": true, "expired": true, "trunc": true} {
+		if ok, err := s.Add(ctx, key, time.Hour); err != nil || ok != want {
+			t.Errorf("Add(%s) after reload = %v, %v, want %v", key, ok, err, want)
+		}
+	}
+}
+
+func TestFileStoreCompacts(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dedup.log")
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	s.Add(ctx, "live", time.Hour)
+
+	maxLines := 0
+	for i := 0; i < 5*compactSlack; i++ {
+		s.Add(ctx, fmt.Sprint("k", i), time.Nanosecond)
+		if i%100 == 0 {
+			b, _ := os.ReadFile(path)
+			maxLines = max(maxLines, bytes.Count(b, []byte("\n")))
+		}
+	}
+	if maxLines > 2*compactSlack {
+		t.Errorf("log grew to %d lines, want at most %d", maxLines, 2*compactSlack)
+	}
+
+	// The compacted log still holds the live key.
+	s.Close()
+	s, err = NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Add(ctx, "live", time.Hour); ok {
+		t.Error("Add(live) after compaction and reload = true")
+	}
+}
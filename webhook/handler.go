@@ -0,0 +1,404 @@
+// Package webhook provides an http.Handler that verifies billing.io webhook
+// deliveries and dispatches them to typed callbacks.
+//
+//	h := webhook.NewHandler(os.Getenv("BILLING_WEBHOOK_SECRET"))
+//	h.OnCheckoutCompleted(func(ctx context.Context, event *billingio.WebhookEvent) error {
+//	    checkout, err := event.AsCheckout()
+//	    if err != nil {
+//	        return webhook.Permanent(err)
+//	    }
+//	    return fulfil(ctx, checkout)
+//	})
+//	http.Handle("/webhooks/billing", h)
+//
+// The handler answers with a status code that tells billing.io whether to
+// redeliver: 2xx once the event was handled (or has no registered callback),
+// 4xx for deliveries that can never succeed, such as a bad signature or an
+// error wrapped with Permanent, and 5xx for other callback errors, which are
+// retried.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	billingio "github.com/billing-io/billing-go"
+)
+
+const (
+	// DefaultMaxBodyBytes is the default limit on the size of a delivery body.
+	DefaultMaxBodyBytes = 1 << 20 // 1 MiB
+
+	// DefaultEventTTL is how long processed event IDs are remembered by
+	// default.
+	DefaultEventTTL = 72 * time.Hour
+)
+
+// HandlerFunc handles a verified webhook event. Returning an error causes the
+// delivery to be retried, unless the error is wrapped with Permanent.
+type HandlerFunc func(ctx context.Context, event *billingio.WebhookEvent) error
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTolerance sets the maximum age in seconds of a delivery's signature
+// timestamp. Defaults to billingio.DefaultTolerance; 0 disables the check.
+func WithTolerance(seconds int) Option {
+	return func(h *Handler) {
+		h.tolerance = seconds
+	}
+}
+
+// WithMaxBodyBytes sets the largest accepted delivery body. Larger bodies are
+// rejected with 413. Defaults to DefaultMaxBodyBytes.
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// WithSecrets adds signing secrets accepted alongside the one passed to
+// NewHandler, for use while an endpoint's secret is being rotated with
+// WebhookService.RollSecret.
+func WithSecrets(secrets ...string) Option {
+	return func(h *Handler) {
+		h.secrets = append(h.secrets, secrets...)
+	}
+}
+
+// WithDedupStore enables replay protection and event deduplication backed
+// by store. A delivery whose signature header was already accepted is
+// rejected with 409, and an event whose EventID was already processed
+// successfully is acknowledged without calling its callback again. Both are
+// forgotten when the callback fails, so that the retry is handled.
+//
+//	h := webhook.NewHandler(secret, webhook.WithDedupStore(webhook.NewMemoryStore(0)))
+func WithDedupStore(store DedupStore) Option {
+	return func(h *Handler) {
+		h.dedup = store
+	}
+}
+
+// WithEventTTL sets how long processed event IDs are remembered by the
+// DedupStore. Defaults to DefaultEventTTL; it should exceed the period over
+// which billing.io redelivers failed events.
+func WithEventTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.eventTTL = ttl
+	}
+}
+
+// WithLogger logs rejected deliveries and callback errors to l.
+func WithLogger(l *slog.Logger) Option {
+	return func(h *Handler) {
+		h.logger = l
+	}
+}
+
+// Handler is an http.Handler for billing.io webhook deliveries. Create one
+// with NewHandler and register callbacks before serving requests.
+type Handler struct {
+	secrets      []string
+	tolerance    int
+	maxBodyBytes int64
+	logger       *slog.Logger
+	dedup        DedupStore
+	eventTTL     time.Duration
+
+	handlers map[billingio.EventType]HandlerFunc
+	fallback HandlerFunc
+}
+
+// NewHandler returns a Handler that verifies deliveries signed with secret
+// (the endpoint's whsec_ signing secret). Use WithSecrets to accept further
+// secrets during rotation.
+func NewHandler(secret string, opts ...Option) *Handler {
+	h := &Handler{
+		secrets:      []string{secret},
+		tolerance:    billingio.DefaultTolerance,
+		maxBodyBytes: DefaultMaxBodyBytes,
+		eventTTL:     DefaultEventTTL,
+		handlers:     make(map[billingio.EventType]HandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// On registers fn for events of type t, replacing any previous callback.
+func (h *Handler) On(t billingio.EventType, fn HandlerFunc) {
+	h.handlers[t] = fn
+}
+
+// Default registers fn for event types without a callback of their own.
+// Without a default, such events are acknowledged and ignored.
+func (h *Handler) Default(fn HandlerFunc) {
+	h.fallback = fn
+}
+
+// OnCheckoutCreated registers fn for checkout.created events.
+func (h *Handler) OnCheckoutCreated(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutCreated, fn)
+}
+
+// OnCheckoutPaymentDetected registers fn for checkout.payment_detected events.
+func (h *Handler) OnCheckoutPaymentDetected(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutPaymentDetected, fn)
+}
+
+// OnCheckoutConfirming registers fn for checkout.confirming events.
+func (h *Handler) OnCheckoutConfirming(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutConfirming, fn)
+}
+
+// OnCheckoutCompleted registers fn for checkout.completed events.
+func (h *Handler) OnCheckoutCompleted(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutCompleted, fn)
+}
+
+// OnCheckoutExpired registers fn for checkout.expired events.
+func (h *Handler) OnCheckoutExpired(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutExpired, fn)
+}
+
+// OnCheckoutFailed registers fn for checkout.failed events.
+func (h *Handler) OnCheckoutFailed(fn HandlerFunc) {
+	h.On(billingio.EventTypeCheckoutFailed, fn)
+}
+
+// OnSubscriptionCreated registers fn for subscription.created events.
+func (h *Handler) OnSubscriptionCreated(fn HandlerFunc) {
+	h.On(billingio.EventTypeSubscriptionCreated, fn)
+}
+
+// OnSubscriptionUpdated registers fn for subscription.updated events.
+func (h *Handler) OnSubscriptionUpdated(fn HandlerFunc) {
+	h.On(billingio.EventTypeSubscriptionUpdated, fn)
+}
+
+// OnSubscriptionPaused registers fn for subscription.paused events.
+func (h *Handler) OnSubscriptionPaused(fn HandlerFunc) {
+	h.On(billingio.EventTypeSubscriptionPaused, fn)
+}
+
+// OnSubscriptionCancelled registers fn for subscription.cancelled events.
+func (h *Handler) OnSubscriptionCancelled(fn HandlerFunc) {
+	h.On(billingio.EventTypeSubscriptionCancelled, fn)
+}
+
+// OnSubscriptionExpired registers fn for subscription.expired events.
+func (h *Handler) OnSubscriptionExpired(fn HandlerFunc) {
+	h.On(billingio.EventTypeSubscriptionExpired, fn)
+}
+
+// OnRenewalPaid registers fn for renewal.paid events.
+func (h *Handler) OnRenewalPaid(fn HandlerFunc) {
+	h.On(billingio.EventTypeRenewalPaid, fn)
+}
+
+// OnRenewalFailed registers fn for renewal.failed events.
+func (h *Handler) OnRenewalFailed(fn HandlerFunc) {
+	h.On(billingio.EventTypeRenewalFailed, fn)
+}
+
+// OnPayoutCompleted registers fn for payout.completed events.
+func (h *Handler) OnPayoutCompleted(fn HandlerFunc) {
+	h.On(billingio.EventTypePayoutCompleted, fn)
+}
+
+// OnPayoutFailed registers fn for payout.failed events.
+func (h *Handler) OnPayoutFailed(fn HandlerFunc) {
+	h.On(billingio.EventTypePayoutFailed, fn)
+}
+
+// OnSettlementCreated registers fn for settlement.created events.
+func (h *Handler) OnSettlementCreated(fn HandlerFunc) {
+	h.On(billingio.EventTypeSettlementCreated, fn)
+}
+
+// ServeHTTP verifies the delivery and dispatches it to the registered
+// callback.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.reject(w, r, http.StatusRequestEntityTooLarge, "payload too large", err)
+			return
+		}
+		h.reject(w, r, http.StatusBadRequest, "failed to read body", err)
+		return
+	}
+
+	v, err := billingio.VerifyWebhook(body, r.Header.Get(billingio.SignatureHeader), h.secrets, h.tolerance)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, "invalid signature", err)
+		return
+	}
+	event := v.Event
+	if h.logger != nil && v.SecretIndex > 0 {
+		h.logger.LogAttrs(r.Context(), slog.LevelDebug, "billingio: webhook verified with additional secret",
+			slog.String("event_id", event.EventID),
+			slog.Int("secret_index", v.SecretIndex),
+		)
+	}
+
+	fn := h.handlers[event.Type]
+	if fn == nil {
+		fn = h.fallback
+	}
+	if fn == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if h.dedup != nil {
+		status, err := h.claim(r, v)
+		if err != nil {
+			h.reject(w, r, status, http.StatusText(status), err)
+			return
+		}
+		if status != 0 {
+			w.WriteHeader(status) // duplicate event, already processed
+			return
+		}
+	}
+
+	if err := h.call(r.Context(), fn, event); err != nil {
+		if h.dedup != nil {
+			// Forget the delivery so that billing.io's redelivery is
+			// handled, even if it carries the same signature header.
+			if rerr := h.release(r, v); rerr != nil {
+				err = errors.Join(err, rerr)
+			}
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			h.reject(w, r, http.StatusUnprocessableEntity, "event rejected", err)
+			return
+		}
+		h.reject(w, r, http.StatusInternalServerError, "event handling failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// claim consults the DedupStore before an event is handled. It returns a
+// non-zero status if the delivery must not be handled: 409 with an error for
+// a replayed signature, 200 for an already processed event, and 500 with an
+// error if the store fails.
+func (h *Handler) claim(r *http.Request, v *billingio.VerifiedWebhook) (int, error) {
+	// A signature is only valid within the timestamp tolerance, so it
+	// needs to be remembered for that long either side of its timestamp.
+	sigTTL := h.eventTTL
+	if h.tolerance > 0 {
+		sigTTL = 2 * time.Duration(h.tolerance) * time.Second
+	}
+	sig := signatureKey(v)
+	added, err := h.dedup.Add(r.Context(), sig, sigTTL)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("webhook: dedup store: %w", err)
+	}
+	if !added {
+		return http.StatusConflict, errors.New("webhook: replayed delivery")
+	}
+
+	event := v.Event
+	if event.EventID == "" {
+		return 0, nil
+	}
+	added, err = h.dedup.Add(r.Context(), eventKey(event.EventID), h.eventTTL)
+	if err != nil {
+		// The delivery was not handled, so its retry must not be
+		// rejected as a replay.
+		if rerr := h.dedup.Remove(r.Context(), sig); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("webhook: dedup store: %w", err)
+	}
+	if !added {
+		if h.logger != nil {
+			h.logger.LogAttrs(r.Context(), slog.LevelInfo, "billingio: duplicate webhook event skipped",
+				slog.String("event_id", event.EventID),
+			)
+		}
+		return http.StatusOK, nil
+	}
+	return 0, nil
+}
+
+// release removes the keys claimed for a delivery whose callback failed.
+func (h *Handler) release(r *http.Request, v *billingio.VerifiedWebhook) error {
+	err := h.dedup.Remove(r.Context(), signatureKey(v))
+	if id := v.Event.EventID; id != "" {
+		err = errors.Join(err, h.dedup.Remove(r.Context(), eventKey(id)))
+	}
+	return err
+}
+
+// signatureKey is the DedupStore key of an accepted delivery. It is built
+// from the verified timestamp and signature rather than the raw header, so
+// that a replay cannot evade it by reformatting the header.
+func signatureKey(v *billingio.VerifiedWebhook) string {
+	return fmt.Sprintf("sig:%d.%s", v.Timestamp, v.Signature)
+}
+
+// eventKey is the DedupStore key of a processed event.
+func eventKey(eventID string) string {
+	return "event:" + eventID
+}
+
+// call runs fn, converting a panic into an error so the delivery is retried.
+func (h *Handler) call(ctx context.Context, fn HandlerFunc, event *billingio.WebhookEvent) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("webhook: callback panicked: %v", p)
+		}
+	}()
+	return fn(ctx, event)
+}
+
+// reject writes an error response and logs err.
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, msg string, err error) {
+	if h.logger != nil {
+		level := slog.LevelWarn
+		if status >= 500 {
+			level = slog.LevelError
+		}
+		h.logger.LogAttrs(r.Context(), level, "billingio: webhook delivery rejected",
+			slog.Int("status", status),
+			slog.String("error", err.Error()),
+		)
+	}
+	http.Error(w, msg, status)
+}
+
+// Permanent wraps err to mark the delivery as one that will never succeed,
+// such as an event for an order that does not exist. The Handler answers
+// with 422 so billing.io does not keep redelivering it.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// permanentError marks a callback error as not worth retrying.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
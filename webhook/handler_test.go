@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	billingio "github.com/billing-io/billing-go"
+)
+
+const testSecret = "whsec_test"
+
+// delivery returns a POST of payload carrying header as its signature.
+func delivery(payload, header string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	r.Header.Set(billingio.SignatureHeader, header)
+	return r
+}
+
+func serve(h http.Handler, r *http.Request) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Code
+}
+
+// countingHandler returns a Handler with a MemoryStore and a checkout.completed
+// callback that counts its calls and returns the error from fail.
+func countingHandler(calls *int, fail func() error) *Handler {
+	h := NewHandler(testSecret, WithDedupStore(NewMemoryStore(0)))
+	h.OnCheckoutCompleted(func(ctx context.Context, event *billingio.WebhookEvent) error {
+		*calls++
+		return fail()
+	})
+	return h
+}
+
+func succeed() error { return nil }
+
+func TestHandlerRejectsReplay(t *testing.T) {
+	// Without an EventID only the signature protects against replays.
+	payload := `{"type":"checkout.completed"}`
+	sig := billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now())
+
+	var calls int
+	h := countingHandler(&calls, succeed)
+	tests := []struct {
+		header string
+		want   int
+	}{
+		{sig, http.StatusOK},
+		{sig, http.StatusConflict},
+		// Reformatted headers that still verify are the same delivery.
+		{sig + ",v0=x", http.StatusConflict},
+		{" " + sig, http.StatusConflict},
+		{strings.Replace(sig, ",", " , ", 1), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		if got := serve(h, delivery(payload, tt.header)); got != tt.want {
+			t.Errorf("header %q: status %d, want %d", tt.header, got, tt.want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("callback ran %d times, want 1", calls)
+	}
+
+	// A redelivery is signed at a new time and is handled.
+	later := billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now().Add(time.Second))
+	if got := serve(h, delivery(payload, later)); got != http.StatusOK || calls != 2 {
+		t.Errorf("redelivery: status %d, %d calls, want 200 and 2 calls", got, calls)
+	}
+}
+
+func TestHandlerSkipsDuplicateEvent(t *testing.T) {
+	payload := `{"event_id":"evt_1","type":"checkout.completed"}`
+	var calls int
+	h := countingHandler(&calls, succeed)
+
+	for i := 0; i < 2; i++ {
+		sig := billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now().Add(time.Duration(i)*time.Second))
+		if got := serve(h, delivery(payload, sig)); got != http.StatusOK {
+			t.Errorf("delivery %d: status %d, want 200", i, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("callback ran %d times, want 1", calls)
+	}
+}
+
+func TestHandlerReleasesKeysOnError(t *testing.T) {
+	payload := `{"event_id":"evt_1","type":"checkout.completed"}`
+	sig := billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now())
+
+	var calls int
+	err := errors.New("database unavailable")
+	h := countingHandler(&calls, func() error { return err })
+
+	if got := serve(h, delivery(payload, sig)); got != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500", got)
+	}
+
+	// The retry reuses the same signed header and must be handled.
+	err = nil
+	if got := serve(h, delivery(payload, sig)); got != http.StatusOK {
+		t.Errorf("retry: status %d, want 200", got)
+	}
+	if got := serve(h, delivery(payload, sig)); got != http.StatusConflict {
+		t.Errorf("replay after success: status %d, want 409", got)
+	}
+	if calls != 2 {
+		t.Errorf("callback ran %d times, want 2", calls)
+	}
+}
+
+func TestHandlerStatusCodes(t *testing.T) {
+	payload := `{"event_id":"evt_1","type":"checkout.completed"}`
+	sig := billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now())
+
+	var calls int
+	h := countingHandler(&calls, func() error { return Permanent(errors.New("no such order")) })
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		want int
+	}{
+		{"permanent", delivery(payload, sig), http.StatusUnprocessableEntity},
+		{"bad signature", delivery(payload, billingio.SignWebhookPayload([]byte(payload), "whsec_other", time.Now())), http.StatusBadRequest},
+		{"stale", delivery(payload, billingio.SignWebhookPayload([]byte(payload), testSecret, time.Now().Add(-time.Hour))), http.StatusBadRequest},
+		{"unhandled type", delivery(`{"type":"payout.failed"}`, billingio.SignWebhookPayload([]byte(`{"type":"payout.failed"}`), testSecret, time.Now())), http.StatusOK},
+		{"method", httptest.NewRequest(http.MethodGet, "/", nil), http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		if got := serve(h, tt.r); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
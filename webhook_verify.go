@@ -48,14 +48,57 @@ func VerifyWebhookSignature(payload []byte, header string, secret string) (*Webh
 //
 // Set tolerance to 0 to disable timestamp checking entirely.
 func VerifyWebhookSignatureWithTolerance(payload []byte, header string, secret string, tolerance int) (*WebhookEvent, error) {
+	event, _, err := VerifyWebhookSignatureWithSecrets(payload, header, []string{secret}, tolerance)
+	return event, err
+}
+
+// VerifyWebhookSignatureWithSecrets is like VerifyWebhookSignatureWithTolerance
+// but accepts several signing secrets, for use while an endpoint's secret is
+// being rotated (see WebhookService.RollSecret). The delivery is accepted if
+// any v1 signature in the header matches any of the secrets.
+//
+// It returns the index in secrets of the secret that matched, so callers can
+// tell when deliveries are no longer signed with a retiring secret.
+func VerifyWebhookSignatureWithSecrets(payload []byte, header string, secrets []string, tolerance int) (*WebhookEvent, int, error) {
+	v, err := VerifyWebhook(payload, header, secrets, tolerance)
+	if err != nil {
+		return nil, -1, err
+	}
+	return v.Event, v.SecretIndex, nil
+}
+
+// VerifiedWebhook is a delivery verified by VerifyWebhook.
+type VerifiedWebhook struct {
+	Event *WebhookEvent
+
+	// Timestamp is the signing time from the header's t= segment.
+	Timestamp int64
+
+	// Signature is the v1 signature that matched. Together with Timestamp
+	// it identifies the delivery regardless of how the rest of the header
+	// is formatted, e.g. for replay protection.
+	Signature string
+
+	// SecretIndex is the index in secrets of the secret that matched.
+	SecretIndex int
+}
+
+// VerifyWebhook is like VerifyWebhookSignatureWithSecrets but also returns
+// the timestamp and signature that were verified.
+func VerifyWebhook(payload []byte, header string, secrets []string, tolerance int) (*VerifiedWebhook, error) {
 	if header == "" {
 		return nil, &WebhookVerificationError{Message: "missing signature header"}
 	}
-	if secret == "" {
+	if len(secrets) == 0 {
 		return nil, &WebhookVerificationError{Message: "missing webhook secret"}
 	}
+	for _, secret := range secrets {
+		if secret == "" {
+			return nil, &WebhookVerificationError{Message: "missing webhook secret"}
+		}
+	}
 
-	timestamp, signature, err := parseSignatureHeader(header)
+	timestamp, signatures, err := parseSignatureHeader(header)
 	if err != nil {
 		return nil, err
 	}
@@ -74,16 +117,21 @@ func VerifyWebhookSignatureWithTolerance(payload []byte, header string, secret s
 		}
 	}
 
-	// Build the signed payload: "{timestamp}.{body}"
-	signedPayload := fmt.Sprintf("%d.%s", timestamp, string(payload))
-
-	// Compute HMAC-SHA256
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(signedPayload))
-	expected := hex.EncodeToString(mac.Sum(nil))
-
-	// Constant-time comparison
-	if !hmac.Equal([]byte(expected), []byte(signature)) {
+	matched, signature := -1, ""
+	for i, secret := range secrets {
+		expected := computeSignature(payload, secret, timestamp)
+		for _, sig := range signatures {
+			// Constant-time comparison
+			if hmac.Equal([]byte(expected), []byte(sig)) {
+				matched, signature = i, expected
+				break
+			}
+		}
+		if matched >= 0 {
+			break
+		}
+	}
+	if matched < 0 {
 		return nil, &WebhookVerificationError{Message: "signature mismatch"}
 	}
 
@@ -93,40 +141,63 @@ func VerifyWebhookSignatureWithTolerance(payload []byte, header string, secret s
 		return nil, &WebhookVerificationError{Message: "invalid JSON in webhook body"}
 	}
 
-	return &event, nil
+	return &VerifiedWebhook{
+		Event:       &event,
+		Timestamp:   timestamp,
+		Signature:   signature,
+		SecretIndex: matched,
+	}, nil
+}
+
+// computeSignature returns the hex HMAC-SHA256 of "{timestamp}.{payload}"
+// under secret.
+func computeSignature(payload []byte, secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
-// parseSignatureHeader extracts the timestamp and v1 signature from the header.
-// Expected format: t={unix_timestamp},v1={hex_hmac_sha256}
-func parseSignatureHeader(header string) (int64, string, error) {
-	parts := make(map[string]string)
+// parseSignatureHeader extracts the timestamp and every v1 signature from the
+// header. Expected format: t={unix_timestamp},v1={hex_hmac_sha256}[,v1=...]
+func parseSignatureHeader(header string) (int64, []string, error) {
+	var (
+		tsStr      string
+		signatures []string
+	)
 	for _, segment := range strings.Split(header, ",") {
 		kv := strings.SplitN(strings.TrimSpace(segment), "=", 2)
-		if len(kv) == 2 {
-			parts[kv[0]] = kv[1]
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			tsStr = kv[1]
+		case "v1":
+			if kv[1] != "" {
+				signatures = append(signatures, kv[1])
+			}
 		}
 	}
 
-	tsStr, ok := parts["t"]
-	if !ok || tsStr == "" {
-		return 0, "", &WebhookVerificationError{
+	if tsStr == "" {
+		return 0, nil, &WebhookVerificationError{
 			Message: "invalid signature header format: missing timestamp (t=)",
 		}
 	}
 
 	timestamp, err := strconv.ParseInt(tsStr, 10, 64)
 	if err != nil {
-		return 0, "", &WebhookVerificationError{
+		return 0, nil, &WebhookVerificationError{
 			Message: "invalid signature header format: non-numeric timestamp",
 		}
 	}
 
-	sig, ok := parts["v1"]
-	if !ok || sig == "" {
-		return 0, "", &WebhookVerificationError{
+	if len(signatures) == 0 {
+		return 0, nil, &WebhookVerificationError{
 			Message: "invalid signature header format: missing signature (v1=)",
 		}
 	}
 
-	return timestamp, sig, nil
+	return timestamp, signatures, nil
 }